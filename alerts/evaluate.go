@@ -0,0 +1,166 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"neongecko/api"
+	"neongecko/models"
+)
+
+// Check is the outcome of evaluating one rule.
+type Check struct {
+	Rule    Rule        `json:"rule"`
+	Coin    models.Coin `json:"coin"`
+	Average float64     `json:"average,omitempty"` // Moving average, for crossing rules
+	Side    int         `json:"-"`
+	Fired   bool        `json:"fired"`
+	Message string      `json:"message"`
+	Err     error       `json:"-"` // Why the rule couldn't be checked; it never fires then
+}
+
+// Evaluate checks rules against current market data from provider. Prices
+// are fetched in one request per currency, and each crossing rule fetches
+// the price history its average needs. Rules still cooling down at now are
+// checked but never fire. A rule whose data can't be fetched gets a check
+// with Err set, without holding up the others. Cached data past its TTL is
+// never used, so alerts don't fire on stale prices. Evaluate doesn't change
+// the rules; pass the checks to RuleSet.Apply to record them.
+func Evaluate(ctx context.Context, provider api.Provider, rules []Rule, now time.Time) ([]Check, error) {
+	ctx = api.Fresh(ctx)
+	coins, err := fetchCoins(ctx, provider, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []Check
+	for _, rule := range rules {
+		coin, ok := coins[rule.Currency][rule.CoinID]
+		if !ok {
+			// Unknown coin or delisted; nothing to compare against
+			err := &api.NotFoundError{Resource: fmt.Sprintf("coin '%s'", rule.CoinID)}
+			checks = append(checks, Check{
+				Rule:    rule,
+				Coin:    models.Coin{ID: rule.CoinID, Symbol: rule.CoinID, Name: rule.CoinID, Currency: rule.Currency},
+				Message: fmt.Sprintf("Couldn't check %s: %v", rule, err),
+				Err:     err,
+			})
+			continue
+		}
+
+		check := Check{Rule: rule, Coin: coin}
+		price := coin.CurrentPrice
+		change := coin.PriceChangePercentage24h
+
+		switch rule.Condition {
+		case PriceAbove:
+			check.Fired = price > rule.Value
+		case PriceBelow:
+			check.Fired = price < rule.Value
+		case ChangeAbove:
+			check.Fired = change > rule.Value
+		case ChangeBelow:
+			check.Fired = change < rule.Value
+		case CrossesAbove, CrossesBelow, Crosses:
+			average, err := movingAverage(ctx, provider, rule)
+			if err != nil {
+				check.Err = err
+				check.Message = fmt.Sprintf("Couldn't check %s: %v", rule, err)
+				checks = append(checks, check)
+				continue
+			}
+			check.Average = average
+			check.Side = sideBelow
+			if price >= average {
+				check.Side = sideAbove
+			}
+
+			// The first look only establishes the side; crossing needs a change
+			crossed := rule.Side != sideUnknown && rule.Side != check.Side
+			switch rule.Condition {
+			case CrossesAbove:
+				check.Fired = crossed && check.Side == sideAbove
+			case CrossesBelow:
+				check.Fired = crossed && check.Side == sideBelow
+			default:
+				check.Fired = crossed
+			}
+		}
+
+		check.Fired = check.Fired && rule.Ready(now)
+		check.Message = message(check)
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// fetchCoins gets market data for every coin the rules mention, keyed by
+// currency and coin ID.
+func fetchCoins(ctx context.Context, provider api.Provider, rules []Rule) (map[string]map[string]models.Coin, error) {
+	ids := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		key := rule.Currency + "/" + rule.CoinID
+		if !seen[key] {
+			seen[key] = true
+			ids[rule.Currency] = append(ids[rule.Currency], rule.CoinID)
+		}
+	}
+
+	coins := make(map[string]map[string]models.Coin)
+	for currency, coinIDs := range ids {
+		markets, err := provider.GetMarketsContext(ctx, api.MarketsQuery{
+			Currency: currency,
+			IDs:      coinIDs,
+			PerPage:  len(coinIDs),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		coins[currency] = make(map[string]models.Coin)
+		for _, coin := range markets {
+			coins[currency][coin.ID] = coin
+		}
+	}
+
+	return coins, nil
+}
+
+// movingAverage is the mean price over the rule's number of days.
+func movingAverage(ctx context.Context, provider api.Provider, rule Rule) (float64, error) {
+	history, err := provider.GetPriceHistoryContext(ctx, rule.CoinID, rule.Currency, strconv.Itoa(int(rule.Value)))
+	if err != nil {
+		return 0, err
+	}
+	if len(history.Prices) == 0 {
+		return 0, fmt.Errorf("no price history for %s", rule.CoinID)
+	}
+
+	var sum float64
+	for _, point := range history.Prices {
+		sum += point.Price
+	}
+	return sum / float64(len(history.Prices)), nil
+}
+
+func message(check Check) string {
+	coin := check.Coin
+	price := formatValue(coin.CurrentPrice) + " " + check.Rule.currencyCode()
+
+	switch check.Rule.Condition {
+	case PriceAbove, PriceBelow:
+		return fmt.Sprintf("%s is at %s (%s)", coin.Name, price, check.Rule)
+	case ChangeAbove, ChangeBelow:
+		return fmt.Sprintf("%s is %+.2f%% in 24h (%s)", coin.Name, coin.PriceChangePercentage24h, check.Rule)
+	}
+
+	side := "below"
+	if check.Side == sideAbove {
+		side = "above"
+	}
+	return fmt.Sprintf("%s at %s is %s its %d-day average of %.2f", coin.Name, price, side, int(check.Rule.Value), check.Average)
+}
@@ -0,0 +1,95 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"neongecko/api"
+	"neongecko/models"
+)
+
+// stubProvider serves fixed market data and search results; history
+// requests fail for coins in historyErr.
+type stubProvider struct {
+	api.Provider
+	coins      []models.Coin
+	currencies []string
+	historyErr map[string]error
+}
+
+func (p stubProvider) SearchCoinsContext(ctx context.Context, query string) ([]models.Coin, error) {
+	return p.coins, nil
+}
+
+func (p stubProvider) GetSupportedCurrenciesContext(ctx context.Context) ([]string, error) {
+	return p.currencies, nil
+}
+
+func (p stubProvider) GetMarketsContext(ctx context.Context, query api.MarketsQuery) ([]models.Coin, error) {
+	return p.coins, nil
+}
+
+func (p stubProvider) GetPriceHistoryContext(ctx context.Context, coinID string, currency string, days string) (*models.PriceHistory, error) {
+	if err := p.historyErr[coinID]; err != nil {
+		return nil, err
+	}
+	return &models.PriceHistory{CoinID: coinID, Prices: []models.PricePoint{{Price: 100}}}, nil
+}
+
+func TestEvaluateKeepsCheckingAfterHistoryError(t *testing.T) {
+	historyErr := errors.New("history unavailable")
+	provider := stubProvider{
+		coins: []models.Coin{
+			{ID: "bitcoin", Name: "Bitcoin", CurrentPrice: 150},
+			{ID: "solana", Name: "Solana", CurrentPrice: 150},
+		},
+		historyErr: map[string]error{"solana": historyErr},
+	}
+	rules := []Rule{
+		{ID: "1", CoinID: "solana", Condition: Crosses, Value: 50, Currency: "usd"},
+		{ID: "2", CoinID: "bitcoin", Condition: PriceAbove, Value: 100, Currency: "usd"},
+	}
+
+	checks, err := Evaluate(context.Background(), provider, rules, time.Now())
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(checks) != 2 {
+		t.Fatalf("Evaluate() returned %d checks, want 2", len(checks))
+	}
+
+	if !errors.Is(checks[0].Err, historyErr) || checks[0].Fired {
+		t.Errorf("solana check = {Err: %v, Fired: %v}, want the history error and not fired", checks[0].Err, checks[0].Fired)
+	}
+	if checks[1].Err != nil || !checks[1].Fired {
+		t.Errorf("bitcoin check = {Err: %v, Fired: %v}, want fired", checks[1].Err, checks[1].Fired)
+	}
+}
+
+func TestEvaluateReportsCoinsMissingFromMarkets(t *testing.T) {
+	provider := stubProvider{
+		coins: []models.Coin{{ID: "bitcoin", Name: "Bitcoin", CurrentPrice: 150}},
+	}
+	rules := []Rule{
+		{ID: "1", CoinID: "delisted", Condition: PriceBelow, Value: 1, Currency: "usd"},
+		{ID: "2", CoinID: "bitcoin", Condition: PriceAbove, Value: 100, Currency: "usd"},
+	}
+
+	checks, err := Evaluate(context.Background(), provider, rules, time.Now())
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(checks) != 2 {
+		t.Fatalf("Evaluate() returned %d checks, want 2", len(checks))
+	}
+
+	var notFoundErr *api.NotFoundError
+	if !errors.As(checks[0].Err, &notFoundErr) || checks[0].Rule.ID != "1" || checks[0].Fired {
+		t.Errorf("delisted check = {Rule: %s, Err: %v, Fired: %v}, want a NotFoundError and not fired", checks[0].Rule.ID, checks[0].Err, checks[0].Fired)
+	}
+	if checks[1].Err != nil || !checks[1].Fired {
+		t.Errorf("bitcoin check = {Err: %v, Fired: %v}, want fired", checks[1].Err, checks[1].Fired)
+	}
+}
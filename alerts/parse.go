@@ -0,0 +1,251 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"neongecko/api"
+)
+
+// keywords end the coin part of a rule, so coins may be several words long.
+var keywords = map[string]bool{
+	"price": true, "24h": true, "change": true, "crosses": true, "cross": true,
+	"above": true, "below": true, "over": true, "under": true, ">": true, "<": true,
+}
+
+// Parse reads a rule written the way a person would say it:
+//
+//	bitcoin above 100k
+//	eth price below 2,500 eur
+//	eth 24h change below -5%
+//	bitcoin crosses above 50-day average
+//	solana crosses 200d sma cooldown 4h
+//
+// Price thresholds are in currency unless the rule names its own. The coin
+// is left as written; Resolve turns it into a CoinGecko ID.
+func Parse(text, currency string) (Rule, error) {
+	p := parser{tokens: strings.Fields(strings.ToLower(text))}
+	rule := Rule{Currency: strings.ToLower(currency)}
+
+	var coin []string
+	for !p.done() && !keywords[p.peek()] {
+		coin = append(coin, p.next())
+	}
+	if len(coin) == 0 {
+		return Rule{}, fmt.Errorf("expected a coin, e.g. \"bitcoin above 100k\"")
+	}
+	rule.CoinID = strings.Join(coin, " ")
+
+	p.skip("price")
+
+	switch {
+	case p.skip("24h"):
+		p.skip("change")
+		fallthrough
+	case p.skip("change"):
+		above, err := p.direction()
+		if err != nil {
+			return Rule{}, err
+		}
+		percent, err := p.percent()
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Condition, rule.Value = ChangeBelow, percent
+		if above {
+			rule.Condition = ChangeAbove
+		}
+
+	case p.skip("crosses") || p.skip("cross"):
+		rule.Condition = Crosses
+		if p.skip("above") || p.skip("over") {
+			rule.Condition = CrossesAbove
+		} else if p.skip("below") || p.skip("under") {
+			rule.Condition = CrossesBelow
+		}
+		p.skip("the")
+		days, err := p.average()
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Value = float64(days)
+
+	default:
+		above, err := p.direction()
+		if err != nil {
+			return Rule{}, err
+		}
+		price, err := p.amount()
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Condition, rule.Value = PriceBelow, price
+		if above {
+			rule.Condition = PriceAbove
+		}
+		if !p.done() && p.peek() != "cooldown" && p.peek() != "every" {
+			rule.Currency = p.next()
+		}
+	}
+
+	if p.skip("cooldown") || p.skip("every") {
+		if p.done() {
+			return Rule{}, fmt.Errorf("expected a cooldown like 30m or 4h")
+		}
+		cooldown := p.next()
+		if _, err := time.ParseDuration(cooldown); err != nil {
+			return Rule{}, fmt.Errorf("invalid cooldown %q, expected e.g. 30m or 4h", cooldown)
+		}
+		rule.Cooldown = cooldown
+	}
+
+	if !p.done() {
+		return Rule{}, fmt.Errorf("unexpected %q", strings.Join(p.tokens[p.pos:], " "))
+	}
+	return rule, nil
+}
+
+// Resolve replaces the coin as written with its CoinGecko ID, matching IDs
+// first, then symbols and names. Search results come ranked by market cap,
+// so a symbol shared by several coins picks the largest. The rule's
+// currency must be one the provider supports.
+func Resolve(ctx context.Context, provider api.Provider, rule Rule) (Rule, error) {
+	currencies, err := provider.GetSupportedCurrenciesContext(ctx)
+	if err != nil {
+		return Rule{}, err
+	}
+	if !slices.Contains(currencies, rule.Currency) {
+		return Rule{}, fmt.Errorf("unsupported currency %q", rule.Currency)
+	}
+
+	coins, err := provider.SearchCoinsContext(ctx, rule.CoinID)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	for _, match := range []func(id, symbol, name string) bool{
+		func(id, symbol, name string) bool { return id == rule.CoinID },
+		func(id, symbol, name string) bool { return strings.EqualFold(symbol, rule.CoinID) },
+		func(id, symbol, name string) bool { return strings.EqualFold(name, rule.CoinID) },
+	} {
+		for _, coin := range coins {
+			if match(coin.ID, coin.Symbol, coin.Name) {
+				rule.CoinID = coin.ID
+				return rule, nil
+			}
+		}
+	}
+
+	return Rule{}, fmt.Errorf("no coin matches %q", rule.CoinID)
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+// skip consumes the next token if it is word.
+func (p *parser) skip(word string) bool {
+	if p.peek() == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// direction reads above or below, reporting whether it was above.
+func (p *parser) direction() (bool, error) {
+	switch p.next() {
+	case "above", "over", ">":
+		return true, nil
+	case "below", "under", "<":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected above or below")
+}
+
+// amount reads a price like 100k, $2,500 or 1.5m.
+func (p *parser) amount() (float64, error) {
+	text := strings.NewReplacer("$", "", ",", "", "_", "").Replace(p.next())
+
+	multiplier := 1.0
+	if text != "" {
+		switch text[len(text)-1] {
+		case 'k':
+			multiplier = 1e3
+		case 'm':
+			multiplier = 1e6
+		case 'b':
+			multiplier = 1e9
+		case 't':
+			multiplier = 1e12
+		}
+		if multiplier != 1 {
+			text = text[:len(text)-1]
+		}
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 || !finite(value) {
+		return 0, fmt.Errorf("expected a price like 100k or 2,500")
+	}
+	return value * multiplier, nil
+}
+
+// percent reads a change like -5%, 5 % or +10.
+func (p *parser) percent() (float64, error) {
+	text := strings.TrimSuffix(p.next(), "%")
+	p.skip("%")
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || !finite(value) {
+		return 0, fmt.Errorf("expected a percentage like -5%%")
+	}
+	return value, nil
+}
+
+// average reads a moving average length like 50-day average, 50 day ma or
+// 200d sma, returning the number of days.
+func (p *parser) average() (int, error) {
+	text := p.next()
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "-day"), "d")
+	days, err := strconv.Atoi(text)
+	if err != nil || days < 1 {
+		return 0, fmt.Errorf("expected an average like 50-day average")
+	}
+
+	p.skip("day")
+	p.skip("moving")
+	if !(p.skip("average") || p.skip("avg") || p.skip("sma") || p.skip("ma")) {
+		return 0, fmt.Errorf("expected an average like 50-day average")
+	}
+	return days, nil
+}
+
+// finite rules out NaN and infinities, which ParseFloat accepts but rules
+// can't be saved with.
+func finite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
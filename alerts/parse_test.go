@@ -0,0 +1,52 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+
+	"neongecko/models"
+)
+
+func TestParseRejectsNonFinite(t *testing.T) {
+	for _, text := range []string{
+		"bitcoin above nan",
+		"bitcoin below inf",
+		"bitcoin above +infinity",
+		"eth 24h change below nan%",
+		"eth 24h change above -inf",
+	} {
+		if rule, err := Parse(text, "usd"); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", text, rule)
+		}
+	}
+}
+
+func TestResolveChecksCurrency(t *testing.T) {
+	provider := stubProvider{
+		coins:      []models.Coin{{ID: "bitcoin", Symbol: "btc", Name: "Bitcoin"}},
+		currencies: []string{"usd", "eur"},
+	}
+
+	tests := []struct {
+		text    string
+		wantErr bool
+	}{
+		{"btc above 100k", false},
+		{"btc above 100k eur", false},
+		{"btc above 100k foo", true},
+	}
+	for _, tt := range tests {
+		rule, err := Parse(tt.text, "usd")
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.text, err)
+		}
+
+		rule, err = Resolve(context.Background(), provider, rule)
+		if gotErr := err != nil; gotErr != tt.wantErr {
+			t.Errorf("Resolve(%q) error = %v, want error: %v", tt.text, err, tt.wantErr)
+		}
+		if err == nil && rule.CoinID != "bitcoin" {
+			t.Errorf("Resolve(%q) coin = %q, want bitcoin", tt.text, rule.CoinID)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"neongecko/config"
+)
+
+type Condition string
+
+const (
+	PriceAbove   Condition = "price_above"
+	PriceBelow   Condition = "price_below"
+	ChangeAbove  Condition = "change_24h_above" // 24h change, in percent
+	ChangeBelow  Condition = "change_24h_below"
+	CrossesAbove Condition = "crosses_above_sma" // Price moves from below to above the N-day average
+	CrossesBelow Condition = "crosses_below_sma"
+	Crosses      Condition = "crosses_sma" // Either direction
+)
+
+// DefaultCooldown is how long a rule stays quiet after firing unless it
+// sets its own cooldown.
+const DefaultCooldown = time.Hour
+
+// Sides of the moving average a price can be on, for crossing rules.
+const (
+	sideUnknown = 0
+	sideBelow   = -1
+	sideAbove   = 1
+)
+
+type Rule struct {
+	ID        string    `json:"id"`
+	CoinID    string    `json:"coin_id"`
+	Condition Condition `json:"condition"`
+	Value     float64   `json:"value"`              // Price, percent, or average length in days
+	Currency  string    `json:"currency"`           // Currency of price thresholds and averages
+	Cooldown  string    `json:"cooldown,omitempty"` // Duration string like "1h"
+	LastFired time.Time `json:"last_fired,omitempty"`
+	Side      int       `json:"side,omitempty"` // Last side of the average seen by crossing rules
+}
+
+func (r Rule) GetCooldown() time.Duration {
+	duration, err := time.ParseDuration(r.Cooldown)
+	if err != nil || duration < 0 {
+		return DefaultCooldown // Default fallback
+	}
+	return duration
+}
+
+// Ready reports whether the rule's cooldown has passed at now.
+func (r Rule) Ready(now time.Time) bool {
+	return r.LastFired.IsZero() || now.Sub(r.LastFired) >= r.GetCooldown()
+}
+
+// String describes the rule in the same form Parse accepts.
+func (r Rule) String() string {
+	if r.Cooldown != "" {
+		return r.describe() + " cooldown " + r.Cooldown
+	}
+	return r.describe()
+}
+
+func (r Rule) describe() string {
+	currency := r.currencyCode()
+	switch r.Condition {
+	case PriceAbove:
+		return fmt.Sprintf("%s above %s %s", r.CoinID, formatValue(r.Value), currency)
+	case PriceBelow:
+		return fmt.Sprintf("%s below %s %s", r.CoinID, formatValue(r.Value), currency)
+	case ChangeAbove:
+		return fmt.Sprintf("%s 24h change above %s%%", r.CoinID, formatValue(r.Value))
+	case ChangeBelow:
+		return fmt.Sprintf("%s 24h change below %s%%", r.CoinID, formatValue(r.Value))
+	case CrossesAbove:
+		return fmt.Sprintf("%s crosses above %d-day average", r.CoinID, int(r.Value))
+	case CrossesBelow:
+		return fmt.Sprintf("%s crosses below %d-day average", r.CoinID, int(r.Value))
+	case Crosses:
+		return fmt.Sprintf("%s crosses %d-day average", r.CoinID, int(r.Value))
+	}
+	return fmt.Sprintf("%s %s %s", r.CoinID, r.Condition, formatValue(r.Value))
+}
+
+func (r Rule) currencyCode() string {
+	return strings.ToUpper(r.Currency)
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// RuleSet is every alert rule, as persisted in alerts.json.
+type RuleSet struct {
+	Rules  []Rule `json:"rules"`
+	NextID int    `json:"next_id"`
+}
+
+func GetRulesPath() (string, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), "alerts.json"), nil
+}
+
+// LoadRules reads the rules from disk, returning an empty set if none have
+// been saved yet.
+func LoadRules() (*RuleSet, error) {
+	rulesPath, err := GetRulesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(rulesPath)
+	if os.IsNotExist(err) {
+		return &RuleSet{NextID: 1}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alerts file: %w", err)
+	}
+
+	var rules RuleSet
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse alerts file: %w", err)
+	}
+
+	return &rules, nil
+}
+
+func SaveRules(rules *RuleSet) error {
+	rulesPath, err := GetRulesPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+
+	if err := os.WriteFile(rulesPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write alerts file: %w", err)
+	}
+
+	return nil
+}
+
+// Add records rule with a new ID and returns it.
+func (s *RuleSet) Add(rule Rule) Rule {
+	if s.NextID < 1 {
+		s.NextID = 1
+	}
+	rule.ID = strconv.Itoa(s.NextID)
+	s.NextID++
+
+	s.Rules = append(s.Rules, rule)
+	return rule
+}
+
+// Delete removes the rule with the given ID.
+func (s *RuleSet) Delete(id string) error {
+	for i, rule := range s.Rules {
+		if rule.ID == id {
+			s.Rules = append(s.Rules[:i:i], s.Rules[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("alert %s not found", id)
+}
+
+// Apply records the outcome of an evaluation: crossing rules remember which
+// side of the average they saw, and fired rules start their cooldown. It
+// returns the checks that fired for rules still in the set, leaving out
+// rules that started cooling down since they were evaluated, such as ones
+// the daemon fired meanwhile.
+func (s *RuleSet) Apply(checks []Check, now time.Time) []Check {
+	var fired []Check
+	for _, check := range checks {
+		for i := range s.Rules {
+			rule := &s.Rules[i]
+			if rule.ID != check.Rule.ID {
+				continue
+			}
+
+			if check.Side != sideUnknown {
+				rule.Side = check.Side
+			}
+			if check.Fired && rule.Ready(now) {
+				rule.LastFired = now
+				fired = append(fired, check)
+			}
+		}
+	}
+	return fired
+}
@@ -0,0 +1,28 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplySkipsRulesFiredSinceEvaluation(t *testing.T) {
+	now := time.Now()
+	rule := Rule{ID: "1", CoinID: "bitcoin", Condition: PriceAbove, Value: 100, Currency: "usd"}
+	check := Check{Rule: rule, Fired: true}
+
+	// Evaluated as ready, but another process fired it in the meantime
+	rules := &RuleSet{Rules: []Rule{rule}, NextID: 2}
+	rules.Rules[0].LastFired = now.Add(-time.Minute)
+
+	if fired := rules.Apply([]Check{check}, now); len(fired) != 0 {
+		t.Errorf("Apply() fired %d checks for a rule cooling down, want 0", len(fired))
+	}
+	if !rules.Rules[0].LastFired.Equal(now.Add(-time.Minute)) {
+		t.Errorf("Apply() changed LastFired of a rule cooling down")
+	}
+
+	rules.Rules[0].LastFired = time.Time{}
+	if fired := rules.Apply([]Check{check}, now); len(fired) != 1 {
+		t.Errorf("Apply() fired %d checks for a ready rule, want 1", len(fired))
+	}
+}
@@ -0,0 +1,132 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event is a fired alert as sent to sinks.
+type Event struct {
+	RuleID    string    `json:"rule_id"`
+	Rule      string    `json:"rule"`
+	CoinID    string    `json:"coin_id"`
+	CoinName  string    `json:"coin_name"`
+	Price     float64   `json:"price"`
+	Change24h float64   `json:"change_24h"`
+	Average   float64   `json:"average,omitempty"`
+	Currency  string    `json:"currency"`
+	Message   string    `json:"message"`
+	FiredAt   time.Time `json:"fired_at"`
+}
+
+func NewEvent(check Check, firedAt time.Time) Event {
+	return Event{
+		RuleID:    check.Rule.ID,
+		Rule:      check.Rule.String(),
+		CoinID:    check.Coin.ID,
+		CoinName:  check.Coin.Name,
+		Price:     check.Coin.CurrentPrice,
+		Change24h: check.Coin.PriceChangePercentage24h,
+		Average:   check.Average,
+		Currency:  check.Rule.Currency,
+		Message:   check.Message,
+		FiredAt:   firedAt,
+	}
+}
+
+// Sink delivers fired alerts somewhere outside the TUI.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// WriterSink writes one log line per alert.
+type WriterSink struct {
+	W io.Writer
+}
+
+func (s WriterSink) Send(ctx context.Context, event Event) error {
+	_, err := fmt.Fprintf(s.W, "%s ALERT %s\n", event.FiredAt.Format(time.RFC3339), event.Message)
+	return err
+}
+
+// defaultCommandTimeout bounds a CommandSink without a Timeout of its own.
+const defaultCommandTimeout = 30 * time.Second
+
+// CommandSink runs a shell command per alert with the event as JSON on
+// stdin. A command still running after Timeout is killed.
+type CommandSink struct {
+	Command string
+	Timeout time.Duration // 0 for defaultCommandTimeout
+}
+
+func (s CommandSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = defaultCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, shell, flag, s.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	// Killing the shell leaves its children holding the output pipe; stop
+	// waiting on them shortly after
+	cmd.WaitDelay = time.Second
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("alert command failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// WebhookSink POSTs each alert as JSON to a URL.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s WebhookSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCommandSinkKillsHungCommand(t *testing.T) {
+	sink := CommandSink{Command: "sleep 30 | cat", Timeout: 100 * time.Millisecond}
+
+	start := time.Now()
+	err := sink.Send(context.Background(), Event{RuleID: "1"})
+	if err == nil {
+		t.Fatal("Send() = nil, want an error for a command that timed out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send() returned after %s, want it to give up shortly after the timeout", elapsed)
+	}
+}
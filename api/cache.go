@@ -1,72 +1,190 @@
 package api
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
 
-type CacheEntry struct {
-	Data      interface{}
+// staleFor is how long past expiry an entry may still be served while it is
+// refreshed in the background.
+const staleFor = 24 * time.Hour
+
+// cleanupInterval is how often entries past staleFor are dropped.
+const cleanupInterval = time.Minute
+
+type CacheEntry[K comparable, V any] struct {
+	Key       K
+	Data      V
 	ExpiresAt time.Time
 }
 
-type Cache struct {
-	mu      sync.RWMutex
-	entries map[string]CacheEntry
-	ttl     time.Duration
+// CacheStats counts how a cache has been used since it was created.
+type CacheStats struct {
+	Entries   int    `json:"entries"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"` // Entries dropped to make room, not because they expired
+}
+
+// Add returns the sum of both stats, for reporting several caches as one.
+func (s CacheStats) Add(other CacheStats) CacheStats {
+	return CacheStats{
+		Entries:   s.Entries + other.Entries,
+		Hits:      s.Hits + other.Hits,
+		Misses:    s.Misses + other.Misses,
+		Evictions: s.Evictions + other.Evictions,
+	}
+}
+
+// Cache is a thread-safe LRU cache whose entries each expire on their own.
+// Once it holds maxEntries, the least recently used entry is evicted to make
+// room for a new one. Close stops its cleanup goroutine.
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	entries    map[K]*list.Element // Elements hold *CacheEntry[K, V]
+	order      *list.List          // Most recently used first
+	maxEntries int                 // 0 for no limit
+	stats      CacheStats
+	done       chan struct{}
+	closeOnce  sync.Once
 }
 
-func NewCache(ttl time.Duration) *Cache {
-	cache := &Cache{
-		entries: make(map[string]CacheEntry),
-		ttl:     ttl,
+func NewCache[K comparable, V any](maxEntries int) *Cache[K, V] {
+	cache := &Cache[K, V]{
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+		maxEntries: max(maxEntries, 0),
+		done:       make(chan struct{}),
 	}
-	
+
 	// Start cleanup goroutine
 	go cache.cleanup()
-	
+
 	return cache
 }
 
-func (c *Cache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	
-	entry, exists := c.entries[key]
-	if !exists {
-		return nil, false
+// Get returns the entry for key unless it has expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	data, expiresAt, found := c.GetStale(key)
+	if !found || time.Now().After(expiresAt) {
+		c.record(false)
+		var zero V
+		return zero, false
 	}
-	
-	if time.Now().After(entry.ExpiresAt) {
-		delete(c.entries, key)
-		return nil, false
+	c.record(true)
+	return data, true
+}
+
+// GetStale returns the entry for key even if it has expired, as long as it
+// is within staleFor of expiring, along with when it expired or will. It
+// leaves the hit and miss counts alone, since only the caller knows whether
+// a stale entry was actually served; see record.
+func (c *Cache[K, V]) GetStale(key K) (V, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists || time.Now().After(element.Value.(*CacheEntry[K, V]).ExpiresAt.Add(staleFor)) {
+		var zero V
+		return zero, time.Time{}, false
 	}
-	
-	return entry.Data, true
+
+	c.order.MoveToFront(element)
+	entry := element.Value.(*CacheEntry[K, V])
+	return entry.Data, entry.ExpiresAt, true
 }
 
-func (c *Cache) Set(key string, data interface{}) {
+// Set stores data for key until ttl has passed.
+func (c *Cache[K, V]) Set(key K, data V, ttl time.Duration) {
+	c.SetUntil(key, data, time.Now().Add(ttl))
+}
+
+// SetUntil stores data until expiresAt, for values that already spent part
+// of their lifetime elsewhere.
+func (c *Cache[K, V]) SetUntil(key K, data V, expiresAt time.Time) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	
-	c.entries[key] = CacheEntry{
-		Data:      data,
-		ExpiresAt: time.Now().Add(c.ttl),
+
+	entry := &CacheEntry[K, V]{Key: key, Data: data, ExpiresAt: expiresAt}
+	if element, exists := c.entries[key]; exists {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
 	}
 }
 
-func (c *Cache) cleanup() {
-	ticker := time.NewTicker(time.Minute)
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+}
+
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+// record counts a lookup as a hit if its entry was served, or a miss if it
+// had to be fetched.
+func (c *Cache[K, V]) record(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hit {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+}
+
+// Close stops the cleanup goroutine. The cache still works afterwards, but
+// entries past staleFor are only dropped when evicted.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// remove drops element from the cache. The caller holds c.mu.
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*CacheEntry[K, V]).Key)
+}
+
+func (c *Cache[K, V]) cleanup() {
+	ticker := time.NewTicker(cleanupInterval)
 	defer ticker.Stop()
-	
-	for range ticker.C {
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+
 		c.mu.Lock()
 		now := time.Now()
-		for key, entry := range c.entries {
-			if now.After(entry.ExpiresAt) {
-				delete(c.entries, key)
+		for element := c.order.Front(); element != nil; {
+			next := element.Next()
+			if now.After(element.Value.(*CacheEntry[K, V]).ExpiresAt.Add(staleFor)) {
+				c.remove(element)
 			}
+			element = next
 		}
 		c.mu.Unlock()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"neongecko/config"
+)
+
+func TestCacheCountsExpiredEntriesAsMisses(t *testing.T) {
+	cache := NewCache[string, int](0)
+	defer cache.Close()
+
+	cache.Set("fresh", 1, time.Minute)
+	cache.SetUntil("expired", 2, time.Now().Add(-time.Minute))
+
+	cache.Get("fresh")
+	cache.Get("expired")
+	cache.Get("missing")
+
+	want := CacheStats{Entries: 2, Hits: 1, Misses: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLookupCountsStaleEntriesOnlyWhenServed(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.API.DiskCache = new(bool)
+	refresh := func(ctx context.Context) (int, error) { return 0, nil }
+
+	for _, serveStale := range []bool{false, true} {
+		client := NewClient(&cfg)
+		if serveStale {
+			client.ServeStale()
+		}
+		cache := newResultCache[int](&cfg, time.Minute)
+		cache.SetUntil("expired", 1, time.Now().Add(-time.Minute))
+
+		_, served := lookup(context.Background(), client, cache, "expired", refresh)
+
+		want := CacheStats{Entries: 1, Misses: 1}
+		if serveStale {
+			want = CacheStats{Entries: 1, Hits: 1}
+		}
+		if served != serveStale {
+			t.Errorf("serveStale=%v: lookup() served = %v", serveStale, served)
+		}
+		if got := cache.Stats(); got != want {
+			t.Errorf("serveStale=%v: Stats() = %+v, want %+v", serveStale, got, want)
+		}
+
+		cache.Close()
+		client.Close()
+	}
+}
+
+func TestLookupFreshSkipsStaleEntries(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.API.DiskCache = new(bool)
+	client := NewClient(&cfg)
+	defer client.Close()
+	client.ServeStale()
+
+	cache := newResultCache[int](&cfg, time.Minute)
+	defer cache.Close()
+	cache.SetUntil("expired", 1, time.Now().Add(-time.Minute))
+	cache.Set("fresh", 2, time.Minute)
+	refresh := func(ctx context.Context) (int, error) { return 0, nil }
+
+	if _, served := lookup(Fresh(context.Background()), client, cache, "expired", refresh); served {
+		t.Error("lookup() served a stale entry to a Fresh context")
+	}
+	if value, served := lookup(Fresh(context.Background()), client, cache, "fresh", refresh); !served || value != 2 {
+		t.Errorf("lookup() = %v, %v, want the fresh entry", value, served)
+	}
+}
@@ -1,10 +1,12 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"neongecko/config"
@@ -17,8 +19,41 @@ const (
 
 type Client struct {
 	httpClient *http.Client
-	cache      *Cache
+	disk       *DiskCache // nil unless api.disk_cache is enabled
 	config     *config.Config
+	limiter    *RateLimiter
+	baseURL    string
+	serveStale bool
+
+	mu         sync.Mutex
+	refreshing map[string]bool // Cache keys being revalidated in the background
+	flights    flightGroup     // Requests in flight, shared by concurrent callers
+
+	// Memory caches, one per kind of result
+	global     resultCache[*models.GlobalData]
+	coins      resultCache[*models.Coin]
+	markets    resultCache[[]models.Coin]
+	searches   resultCache[[]models.Coin]
+	histories  resultCache[*models.PriceHistory]
+	candles    resultCache[[]models.Candle]
+	currencies resultCache[[]string]
+
+	// Validators and bodies of earlier responses, by URL
+	validators *Cache[string, validated]
+}
+
+// resultCache is the memory cache for one kind of result and how long those
+// results stay fresh by default.
+type resultCache[T any] struct {
+	*Cache[string, T]
+	ttl time.Duration
+}
+
+func newResultCache[T any](cfg *config.Config, ttl time.Duration) resultCache[T] {
+	return resultCache[T]{
+		Cache: NewCache[string, T](cfg.GetCacheMaxEntries()),
+		ttl:   ttl,
+	}
 }
 
 func NewClient(cfg *config.Config) *Client {
@@ -27,40 +62,184 @@ func NewClient(cfg *config.Config) *Client {
 		cfg = &config.DefaultConfig
 	}
 	
+	baseURL := BaseURL
+	if cfg.API.BaseURL != "" {
+		// Self-hosted or proxied CoinGecko-compatible service
+		baseURL = strings.TrimRight(cfg.API.BaseURL, "/")
+	}
+
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: cfg.GetTimeout(),
 		},
-		cache:  NewCache(cfg.GetCacheTTL()),
-		config: cfg,
+		disk:    newDiskCache(cfg),
+		config:  cfg,
+		limiter: NewRateLimiter(cfg.API.RateLimit),
+		baseURL: baseURL,
+
+		refreshing: make(map[string]bool),
+		validators: NewCache[string, validated](cfg.GetCacheMaxEntries()),
+
+		global:     newResultCache[*models.GlobalData](cfg, cfg.GetCacheTTL()),
+		coins:      newResultCache[*models.Coin](cfg, cfg.GetCacheTTL()),
+		markets:    newResultCache[[]models.Coin](cfg, cfg.GetCacheTTL()),
+		searches:   newResultCache[[]models.Coin](cfg, cfg.GetSearchCacheTTL()),
+		histories:  newResultCache[*models.PriceHistory](cfg, cfg.GetCacheTTL()),
+		candles:    newResultCache[[]models.Candle](cfg, cfg.GetCacheTTL()),
+		currencies: newResultCache[[]string](cfg, cfg.GetSearchCacheTTL()),
 	}
 }
 
-func (c *Client) GetGlobalData() (*models.GlobalData, error) {
-	cacheKey := "global_data"
-	
-	// Check cache first
-	if cached, found := c.cache.Get(cacheKey); found {
-		return cached.(*models.GlobalData), nil
+// Close stops the memory caches' cleanup goroutines.
+func (c *Client) Close() {
+	c.validators.Close()
+	c.global.Close()
+	c.coins.Close()
+	c.markets.Close()
+	c.searches.Close()
+	c.histories.Close()
+	c.candles.Close()
+	c.currencies.Close()
+}
+
+// CacheStats adds up the memory caches' hit, miss and eviction counters.
+func (c *Client) CacheStats() CacheStats {
+	return c.global.Stats().
+		Add(c.coins.Stats()).
+		Add(c.markets.Stats()).
+		Add(c.searches.Stats()).
+		Add(c.histories.Stats()).
+		Add(c.candles.Stats()).
+		Add(c.currencies.Stats())
+}
+
+// ServeStale makes the client answer with expired cache entries right away
+// while it refreshes them in the background, so long-running programs never
+// block on data they already have. Programs that exit after one request
+// should leave it off, or they would only ever see stale data.
+func (c *Client) ServeStale() {
+	c.serveStale = true
+}
+
+// newDiskCache opens the disk cache if it is enabled. Without a usable
+// cache directory the client simply runs on the memory cache.
+func newDiskCache(cfg *config.Config) *DiskCache {
+	if !cfg.GetDiskCache() {
+		return nil
 	}
-	
-	url := fmt.Sprintf("%s/global", BaseURL)
-	
-	resp, err := c.httpClient.Get(url)
+
+	dir, err := DefaultDiskCacheDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch global data: %w", err)
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	disk, err := NewDiskCache(dir, cfg.GetDiskCacheMaxBytes())
+	if err != nil {
+		return nil
 	}
+	return disk
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+// skipCache marks a context whose requests must go to the API, for
+// background revalidation.
+type skipCache struct{}
+
+// noStale marks a context whose requests must not be answered with stale
+// data; see Fresh.
+type noStale struct{}
+
+// Fresh returns a context whose requests are answered from the cache only
+// while entries are within their TTL, even by a client that serves stale
+// data, for callers that act on prices rather than just show them.
+func Fresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noStale{}, true)
+}
+
+// lookup finds key in the memory cache, then on disk. Disk hits are kept in
+// memory for the rest of their lifetime. Expired entries are returned when
+// the client serves stale data and ctx isn't Fresh, and refresh is started
+// in the background to replace them.
+func lookup[T any](ctx context.Context, c *Client, cache resultCache[T], key string, refresh func(ctx context.Context) (T, error)) (T, bool) {
+	var value T
+	if ctx.Value(skipCache{}) != nil {
+		return value, false
+	}
+
+	value, expiresAt, found := cache.GetStale(key)
+	if !found {
+		if expiresAt, found = c.disk.Get(key, &value, cache.ttl); found {
+			cache.SetUntil(key, value, expiresAt)
+		}
+	}
+
+	switch {
+	case !found:
+	case time.Now().Before(expiresAt):
+		cache.record(true)
+		return value, true
+	case c.serveStale && ctx.Value(noStale{}) == nil:
+		c.revalidate(key, func(ctx context.Context) error {
+			_, err := refresh(ctx)
+			return err
+		})
+		cache.record(true)
+		return value, true
+	}
+	cache.record(false)
+	return value, false
+}
+
+// revalidate runs refresh in the background unless key is already being
+// refreshed. Failures leave the stale entry in place for the next attempt.
+func (c *Client) revalidate(key string, refresh func(ctx context.Context) error) {
+	c.mu.Lock()
+	if c.refreshing[key] {
+		c.mu.Unlock()
+		return
 	}
+	c.refreshing[key] = true
+	c.mu.Unlock()
 
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, key)
+			c.mu.Unlock()
+		}()
+
+		refresh(context.WithValue(context.Background(), skipCache{}, true))
+	}()
+}
+
+// store caches value in memory and on disk for the cache's TTL.
+func store[T any](c *Client, cache resultCache[T], key string, value T) {
+	cache.Set(key, value, cache.ttl)
+	c.disk.Set(key, value, cache.ttl)
+}
+
+// RateLimitPending reports how long queued requests will be held back by the
+// rate limiter before they are sent.
+func (c *Client) RateLimitPending() time.Duration {
+	return c.limiter.Pending()
+}
+
+func (c *Client) GetGlobalData() (*models.GlobalData, error) {
+	return c.GetGlobalDataContext(context.Background(), c.config.GetCurrency())
+}
+
+func (c *Client) GetGlobalDataContext(ctx context.Context, currency string) (*models.GlobalData, error) {
+	cacheKey := fmt.Sprintf("global_data_%s", currency)
+	
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) (*models.GlobalData, error) {
+		return c.GetGlobalDataContext(ctx, currency)
+	}
+	if cached, found := lookup(ctx, c, c.global, cacheKey, refresh); found {
+		return cached, nil
+	}
+	
+	url := fmt.Sprintf("%s/global", c.baseURL)
+	
 	var response struct {
 		Data struct {
 			TotalMarketCap         map[string]float64 `json:"total_market_cap"`
@@ -69,51 +248,46 @@ func (c *Client) GetGlobalData() (*models.GlobalData, error) {
 		} `json:"data"`
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	if err := c.get(ctx, url, "global market data", &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch global data: %w", err)
 	}
 
 	globalData := &models.GlobalData{
-		TotalMarketCap:         response.Data.TotalMarketCap["usd"],
-		TotalVolume:           response.Data.TotalVolume["usd"],
+		Currency:               currency,
+		TotalMarketCap:         response.Data.TotalMarketCap[currency],
+		TotalVolume:           response.Data.TotalVolume[currency],
 		MarketCapChangePercentage24h: response.Data.MarketCapChangePercentage24h,
+		FetchedAt:              time.Now(),
 	}
 	
 	// Cache the result
-	c.cache.Set(cacheKey, globalData)
+	store(c, c.global, cacheKey, globalData)
 	
 	return globalData, nil
 }
 
 func (c *Client) GetCoinData(coinID string) (*models.Coin, error) {
-	cacheKey := fmt.Sprintf("coin_data_%s", coinID)
+	return c.GetCoinDataContext(context.Background(), coinID, c.config.GetCurrency())
+}
+
+func (c *Client) GetCoinDataContext(ctx context.Context, coinID string, currency string) (*models.Coin, error) {
+	cacheKey := fmt.Sprintf("coin_data_%s_%s", coinID, currency)
 	
-	// Check cache first
-	if cached, found := c.cache.Get(cacheKey); found {
-		return cached.(*models.Coin), nil
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) (*models.Coin, error) {
+		return c.GetCoinDataContext(ctx, coinID, currency)
+	}
+	if cached, found := lookup(ctx, c, c.coins, cacheKey, refresh); found {
+		return cached, nil
 	}
 	
-	url := fmt.Sprintf("%s/coins/%s?localization=false&tickers=false&market_data=true&community_data=false&developer_data=false", BaseURL, coinID)
+	url := fmt.Sprintf("%s/coins/%s?localization=false&tickers=false&market_data=true&community_data=false&developer_data=false", c.baseURL, coinID)
 	
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch coin data: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	var response struct {
 		ID     string `json:"id"`
 		Symbol string `json:"symbol"`
 		Name   string `json:"name"`
+		MarketCapRank int `json:"market_cap_rank"`
 		MarketData struct {
 			CurrentPrice             map[string]float64 `json:"current_price"`
 			MarketCap               map[string]float64 `json:"market_cap"`
@@ -128,89 +302,306 @@ func (c *Client) GetCoinData(coinID string) (*models.Coin, error) {
 			PriceChangePercentage7d  float64           `json:"price_change_percentage_7d"`
 			PriceChangePercentage30d float64           `json:"price_change_percentage_30d"`
 			PriceChangePercentage90d float64           `json:"price_change_percentage_90d"`
+			PriceChange24hInCurrency map[string]float64 `json:"price_change_percentage_24h_in_currency"`
+			PriceChange7dInCurrency  map[string]float64 `json:"price_change_percentage_7d_in_currency"`
+			PriceChange30dInCurrency map[string]float64 `json:"price_change_percentage_30d_in_currency"`
 		} `json:"market_data"`
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	if err := c.get(ctx, url, fmt.Sprintf("coin '%s'", coinID), &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch coin data: %w", err)
 	}
 
-	athDate, _ := time.Parse("2006-01-02T15:04:05.000Z", response.MarketData.AllTimeHighDate["usd"])
-	atlDate, _ := time.Parse("2006-01-02T15:04:05.000Z", response.MarketData.AllTimeLowDate["usd"])
+	athDate, _ := time.Parse("2006-01-02T15:04:05.000Z", response.MarketData.AllTimeHighDate[currency])
+	atlDate, _ := time.Parse("2006-01-02T15:04:05.000Z", response.MarketData.AllTimeLowDate[currency])
 
 	coinData := &models.Coin{
 		ID:                        response.ID,
 		Symbol:                   response.Symbol,
 		Name:                     response.Name,
-		CurrentPrice:             response.MarketData.CurrentPrice["usd"],
-		MarketCap:                response.MarketData.MarketCap["usd"],
-		TotalVolume:              response.MarketData.TotalVolume["usd"],
+		Currency:                 currency,
+		MarketCapRank:            response.MarketCapRank,
+		CurrentPrice:             response.MarketData.CurrentPrice[currency],
+		MarketCap:                response.MarketData.MarketCap[currency],
+		TotalVolume:              response.MarketData.TotalVolume[currency],
 		CirculatingSupply:        response.MarketData.CirculatingSupply,
 		TotalSupply:              response.MarketData.TotalSupply,
-		AllTimeHigh:              response.MarketData.AllTimeHigh["usd"],
+		AllTimeHigh:              response.MarketData.AllTimeHigh[currency],
 		AllTimeHighDate:          athDate,
-		AllTimeLow:               response.MarketData.AllTimeLow["usd"],
+		AllTimeLow:               response.MarketData.AllTimeLow[currency],
 		AllTimeLowDate:           atlDate,
-		PriceChangePercentage24h: response.MarketData.PriceChangePercentage24h,
-		PriceChangePercentage7d:  response.MarketData.PriceChangePercentage7d,
-		PriceChangePercentage30d: response.MarketData.PriceChangePercentage30d,
+		PriceChangePercentage24h: inCurrency(response.MarketData.PriceChange24hInCurrency, currency, response.MarketData.PriceChangePercentage24h),
+		PriceChangePercentage7d:  inCurrency(response.MarketData.PriceChange7dInCurrency, currency, response.MarketData.PriceChangePercentage7d),
+		PriceChangePercentage30d: inCurrency(response.MarketData.PriceChange30dInCurrency, currency, response.MarketData.PriceChangePercentage30d),
 		PriceChangePercentage90d: response.MarketData.PriceChangePercentage90d,
+		FetchedAt:                time.Now(),
 	}
 	
 	// Cache the result
-	c.cache.Set(cacheKey, coinData)
+	store(c, c.coins, cacheKey, coinData)
 	
 	return coinData, nil
 }
 
 func (c *Client) SearchCoins(query string) ([]models.Coin, error) {
+	return c.SearchCoinsContext(context.Background(), query)
+}
+
+func (c *Client) SearchCoinsContext(ctx context.Context, query string) ([]models.Coin, error) {
 	cacheKey := fmt.Sprintf("search_%s", query)
 	
-	// Check cache first
-	if cached, found := c.cache.Get(cacheKey); found {
-		return cached.([]models.Coin), nil
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) ([]models.Coin, error) {
+		return c.SearchCoinsContext(ctx, query)
+	}
+	if cached, found := lookup(ctx, c, c.searches, cacheKey, refresh); found {
+		return cached, nil
 	}
 	
-	url := fmt.Sprintf("%s/search?query=%s", BaseURL, query)
+	url := fmt.Sprintf("%s/search?query=%s", c.baseURL, neturl.QueryEscape(query))
 	
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
+	var response struct {
+		Coins []struct {
+			ID            string `json:"id"`
+			Symbol        string `json:"symbol"`
+			Name          string `json:"name"`
+			MarketCapRank int    `json:"market_cap_rank"`
+		} `json:"coins"`
+	}
+
+	if err := c.get(ctx, url, fmt.Sprintf("coins matching '%s'", query), &response); err != nil {
 		return nil, fmt.Errorf("failed to search coins: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	var coins []models.Coin
+	for _, coin := range response.Coins {
+		coins = append(coins, models.Coin{
+			ID:            coin.ID,
+			Symbol:        coin.Symbol,
+			Name:          coin.Name,
+			MarketCapRank: coin.MarketCapRank,
+		})
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	// Cache the result
+	store(c, c.searches, cacheKey, coins)
+
+	return coins, nil
+}
+
+func (c *Client) GetPriceHistory(coinID string, days string) (*models.PriceHistory, error) {
+	return c.GetPriceHistoryContext(context.Background(), coinID, c.config.GetCurrency(), days)
+}
+
+func (c *Client) GetPriceHistoryContext(ctx context.Context, coinID string, currency string, days string) (*models.PriceHistory, error) {
+	cacheKey := fmt.Sprintf("history_%s_%s_%s", coinID, currency, days)
+
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) (*models.PriceHistory, error) {
+		return c.GetPriceHistoryContext(ctx, coinID, currency, days)
+	}
+	if cached, found := lookup(ctx, c, c.histories, cacheKey, refresh); found {
+		return cached, nil
 	}
 
+	url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=%s&days=%s", c.baseURL, coinID, currency, days)
+
 	var response struct {
-		Coins []struct {
-			ID     string `json:"id"`
-			Symbol string `json:"symbol"`
-			Name   string `json:"name"`
-		} `json:"coins"`
+		Prices [][2]float64 `json:"prices"`
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	if err := c.get(ctx, url, fmt.Sprintf("price history of '%s'", coinID), &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch price history: %w", err)
 	}
 
-	var coins []models.Coin
-	for _, coin := range response.Coins {
+	history := &models.PriceHistory{
+		CoinID:   coinID,
+		Currency: currency,
+		Days:     days,
+	}
+	for _, point := range response.Prices {
+		history.Prices = append(history.Prices, models.PricePoint{
+			Time:  time.UnixMilli(int64(point[0])),
+			Price: point[1],
+		})
+	}
+
+	// Cache the result
+	store(c, c.histories, cacheKey, history)
+
+	return history, nil
+}
+
+// inCurrency picks a per-currency figure from a CoinGecko market data map,
+// falling back to the USD-based value when the currency is missing.
+func inCurrency(values map[string]float64, currency string, fallback float64) float64 {
+	if value, ok := values[currency]; ok {
+		return value
+	}
+	return fallback
+}
+
+func (c *Client) GetOHLC(coinID string, days string) ([]models.Candle, error) {
+	return c.GetOHLCContext(context.Background(), coinID, c.config.GetCurrency(), days)
+}
+
+// GetOHLCContext fetches open/high/low/close candles for coinID. CoinGecko
+// picks the candle size from days: 30 minutes up to 2 days, 4 hours up to 30
+// days and 4 days beyond that.
+func (c *Client) GetOHLCContext(ctx context.Context, coinID string, currency string, days string) ([]models.Candle, error) {
+	cacheKey := fmt.Sprintf("ohlc_%s_%s_%s", coinID, currency, days)
+
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) ([]models.Candle, error) {
+		return c.GetOHLCContext(ctx, coinID, currency, days)
+	}
+	if cached, found := lookup(ctx, c, c.candles, cacheKey, refresh); found {
+		return cached, nil
+	}
+
+	url := fmt.Sprintf("%s/coins/%s/ohlc?vs_currency=%s&days=%s", c.baseURL, coinID, currency, days)
+
+	var response [][5]float64
+	if err := c.get(ctx, url, fmt.Sprintf("candles of '%s'", coinID), &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch OHLC data: %w", err)
+	}
+
+	candles := make([]models.Candle, 0, len(response))
+	for _, candle := range response {
+		candles = append(candles, models.Candle{
+			Time:  time.UnixMilli(int64(candle[0])),
+			Open:  candle[1],
+			High:  candle[2],
+			Low:   candle[3],
+			Close: candle[4],
+		})
+	}
+
+	// Cache the result
+	store(c, c.candles, cacheKey, candles)
+
+	return candles, nil
+}
+
+func (c *Client) GetSupportedCurrencies() ([]string, error) {
+	return c.GetSupportedCurrenciesContext(context.Background())
+}
+
+// GetSupportedCurrenciesContext lists the vs_currency codes prices can be
+// quoted in.
+func (c *Client) GetSupportedCurrenciesContext(ctx context.Context) ([]string, error) {
+	cacheKey := "supported_currencies"
+
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) ([]string, error) {
+		return c.GetSupportedCurrenciesContext(ctx)
+	}
+	if cached, found := lookup(ctx, c, c.currencies, cacheKey, refresh); found {
+		return cached, nil
+	}
+
+	url := fmt.Sprintf("%s/simple/supported_vs_currencies", c.baseURL)
+
+	var currencies []string
+	if err := c.get(ctx, url, "supported currencies", &currencies); err != nil {
+		return nil, fmt.Errorf("failed to fetch supported currencies: %w", err)
+	}
+
+	// Cache the result
+	store(c, c.currencies, cacheKey, currencies)
+
+	return currencies, nil
+}
+
+func (c *Client) GetMarkets(query MarketsQuery) ([]models.Coin, error) {
+	return c.GetMarketsContext(context.Background(), query)
+}
+
+// GetMarketsContext fetches market data for a page of coins, or for just the
+// coins in query.IDs, in a single request.
+func (c *Client) GetMarketsContext(ctx context.Context, query MarketsQuery) ([]models.Coin, error) {
+	if query.Currency == "" {
+		query.Currency = c.config.GetCurrency()
+	}
+
+	params := neturl.Values{}
+	params.Set("vs_currency", query.Currency)
+	params.Set("price_change_percentage", "24h,7d,30d")
+	if len(query.IDs) > 0 {
+		params.Set("ids", strings.Join(query.IDs, ","))
+	}
+	if query.Order != "" {
+		params.Set("order", query.Order)
+	}
+	if query.Page > 0 {
+		params.Set("page", fmt.Sprint(query.Page))
+	}
+	if query.PerPage > 0 {
+		params.Set("per_page", fmt.Sprint(query.PerPage))
+	}
+
+	cacheKey := fmt.Sprintf("markets_%s", params.Encode())
+
+	// Check cache first; stale entries are refreshed with the same call
+	refresh := func(ctx context.Context) ([]models.Coin, error) {
+		return c.GetMarketsContext(ctx, query)
+	}
+	if cached, found := lookup(ctx, c, c.markets, cacheKey, refresh); found {
+		return cached, nil
+	}
+
+	url := fmt.Sprintf("%s/coins/markets?%s", c.baseURL, params.Encode())
+
+	var response []struct {
+		ID                       string    `json:"id"`
+		Symbol                   string    `json:"symbol"`
+		Name                     string    `json:"name"`
+		CurrentPrice             float64   `json:"current_price"`
+		MarketCap                float64   `json:"market_cap"`
+		MarketCapRank            int       `json:"market_cap_rank"`
+		TotalVolume              float64   `json:"total_volume"`
+		CirculatingSupply        float64   `json:"circulating_supply"`
+		TotalSupply              *float64  `json:"total_supply"`
+		AllTimeHigh              float64   `json:"ath"`
+		AllTimeHighDate          time.Time `json:"ath_date"`
+		AllTimeLow               float64   `json:"atl"`
+		AllTimeLowDate           time.Time `json:"atl_date"`
+		PriceChangePercentage24h float64   `json:"price_change_percentage_24h_in_currency"`
+		PriceChangePercentage7d  float64   `json:"price_change_percentage_7d_in_currency"`
+		PriceChangePercentage30d float64   `json:"price_change_percentage_30d_in_currency"`
+	}
+
+	if err := c.get(ctx, url, "markets", &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch markets: %w", err)
+	}
+
+	fetchedAt := time.Now()
+	coins := make([]models.Coin, 0, len(response))
+	for _, coin := range response {
 		coins = append(coins, models.Coin{
-			ID:     coin.ID,
-			Symbol: coin.Symbol,
-			Name:   coin.Name,
+			ID:                       coin.ID,
+			Symbol:                   coin.Symbol,
+			Name:                     coin.Name,
+			Currency:                 query.Currency,
+			MarketCapRank:            coin.MarketCapRank,
+			CurrentPrice:             coin.CurrentPrice,
+			MarketCap:                coin.MarketCap,
+			TotalVolume:              coin.TotalVolume,
+			CirculatingSupply:        coin.CirculatingSupply,
+			TotalSupply:              coin.TotalSupply,
+			AllTimeHigh:              coin.AllTimeHigh,
+			AllTimeHighDate:          coin.AllTimeHighDate,
+			AllTimeLow:               coin.AllTimeLow,
+			AllTimeLowDate:           coin.AllTimeLowDate,
+			PriceChangePercentage24h: coin.PriceChangePercentage24h,
+			PriceChangePercentage7d:  coin.PriceChangePercentage7d,
+			PriceChangePercentage30d: coin.PriceChangePercentage30d,
+			FetchedAt:                fetchedAt,
 		})
 	}
 
 	// Cache the result
-	c.cache.Set(cacheKey, coins)
+	store(c, c.markets, cacheKey, coins)
 
 	return coins, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diskEntry is one cached value as stored on disk.
+type diskEntry struct {
+	Key       string          `json:"key"`
+	StoredAt  time.Time       `json:"stored_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DiskCache keeps API results as JSON files so they survive restarts. Each
+// entry expires on its own, and the oldest entries are evicted once the
+// files exceed maxBytes. A nil *DiskCache caches nothing.
+type DiskCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+}
+
+type DiskCacheStats struct {
+	Dir      string `json:"dir"`
+	Entries  int    `json:"entries"`
+	Expired  int    `json:"expired"`
+	Bytes    int64  `json:"bytes"`
+	MaxBytes int64  `json:"max_bytes"`
+}
+
+// DefaultDiskCacheDir is where the disk cache lives: neongecko/api under
+// the user cache directory.
+func DefaultDiskCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "neongecko", "api"), nil
+}
+
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+	}, nil
+}
+
+// Get decodes the entry for key into v. Entries expire at their own expiry
+// or, if sooner, once they are older than ttl, so a client with a short TTL
+// never takes what a long-lived one stored as fresh. It returns when the
+// entry expires; expired entries are still returned for staleFor
+// afterwards, for serving stale while revalidating.
+func (d *DiskCache) Get(key string, v interface{}, ttl time.Duration) (time.Time, bool) {
+	if d == nil {
+		return time.Time{}, false
+	}
+
+	entry, err := d.read(d.path(key))
+	if err != nil || entry.Key != key {
+		return time.Time{}, false
+	}
+
+	expiresAt := entry.ExpiresAt
+	if byAge := entry.StoredAt.Add(ttl); byAge.Before(expiresAt) {
+		expiresAt = byAge
+	}
+	if time.Now().After(expiresAt.Add(staleFor)) {
+		return time.Time{}, false
+	}
+
+	if err := json.Unmarshal(entry.Data, v); err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// Set stores data for key until ttl has passed, then evicts entries if the
+// cache has grown past its size cap. Failures only cost a future request,
+// so they are ignored.
+func (d *DiskCache) Set(key string, data interface{}, ttl time.Duration) {
+	if d == nil {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	contents, err := json.Marshal(diskEntry{
+		Key:       key,
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+		Data:      raw,
+	})
+	if err != nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Write through a temporary file so readers never see half an entry
+	tmp, err := os.CreateTemp(d.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		return
+	}
+
+	d.evict()
+}
+
+// Clear removes every entry.
+func (d *DiskCache) Clear() error {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	files, err := d.files()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *DiskCache) Stats() (DiskCacheStats, error) {
+	if d == nil {
+		return DiskCacheStats{}, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats := DiskCacheStats{Dir: d.dir, MaxBytes: d.maxBytes}
+	files, err := d.files()
+	if err != nil {
+		return stats, err
+	}
+
+	now := time.Now()
+	for _, file := range files {
+		stats.Entries++
+		stats.Bytes += file.size
+		if entry, err := d.read(file.path); err != nil || !now.Before(entry.ExpiresAt) {
+			stats.Expired++
+		}
+	}
+	return stats, nil
+}
+
+type cacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (d *DiskCache) files() ([]cacheFile, error) {
+	dirEntries, err := os.ReadDir(d.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var files []cacheFile
+	for _, dirEntry := range dirEntries {
+		if !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{
+			path:    filepath.Join(d.dir, dirEntry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+	return files, nil
+}
+
+// evict removes expired entries, then the least recently written ones,
+// until the cache fits in maxBytes. The caller holds d.mu.
+func (d *DiskCache) evict() {
+	if d.maxBytes <= 0 {
+		return
+	}
+
+	files, err := d.files()
+	if err != nil {
+		return
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	if total <= d.maxBytes {
+		return
+	}
+
+	now := time.Now()
+	var live []cacheFile
+	for _, file := range files {
+		if entry, err := d.read(file.path); err != nil || !now.Before(entry.ExpiresAt) {
+			os.Remove(file.path)
+			total -= file.size
+			continue
+		}
+		live = append(live, file)
+	}
+
+	sort.Slice(live, func(i, j int) bool {
+		return live[i].modTime.Before(live[j].modTime)
+	})
+	for _, file := range live {
+		if total <= d.maxBytes {
+			break
+		}
+		os.Remove(file.path)
+		total -= file.size
+	}
+}
+
+func (d *DiskCache) read(path string) (diskEntry, error) {
+	var entry diskEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+// path names the entry file by a hash of key, since keys hold characters
+// that aren't safe in file names.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
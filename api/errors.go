@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxBodySnippet bounds how much of an unexpected response body is kept on
+// an UpstreamError.
+const maxBodySnippet = 200
+
+// NotFoundError reports that the requested coin or resource does not exist.
+type NotFoundError struct {
+	Resource string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.Resource)
+}
+
+// RateLimitError reports that a request was rejected for exceeding a rate
+// limit, either by the API (429) or by the client's own limiter. ResetAt is
+// when a retry is expected to succeed; it is zero when unknown.
+type RateLimitError struct {
+	ResetAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return "rate limited"
+	}
+	return fmt.Sprintf("rate limited until %s", e.ResetAt.Format("15:04:05"))
+}
+
+// RetryAfter is how long to wait before retrying, or zero when unknown.
+func (e *RateLimitError) RetryAfter() time.Duration {
+	if wait := time.Until(e.ResetAt); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// UpstreamError reports an unexpected status code from the API, with the
+// start of the response body for context.
+type UpstreamError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *UpstreamError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("API returned status code: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API returned status code: %d: %s", e.StatusCode, e.Body)
+}
+
+// DecodeError reports a response body that could not be parsed.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to parse JSON response: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// NetworkError reports a failure to reach the API or read its response.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network error: %v", e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+func bodySnippet(body []byte) string {
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) > maxBodySnippet {
+		snippet = snippet[:maxBodySnippet] + "..."
+	}
+	return snippet
+}
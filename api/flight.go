@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent requests for the same key, singleflight
+// style: the first caller starts the request and later callers wait for its
+// result instead of sending their own. The zero value is ready to use.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is one request shared by every caller waiting on it.
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Do returns the result of fetch for key, joining a request for key that is
+// already in flight. Each caller stops waiting when its own ctx is
+// cancelled, and the shared request is cancelled once every caller has
+// given up. The body is shared, so callers must not modify it.
+func (g *flightGroup) Do(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	f, inFlight := g.flights[key]
+	if !inFlight {
+		// Keep ctx's values but not its cancellation, which belongs to
+		// every waiter rather than just the first
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+
+		go func() {
+			f.body, f.err = fetch(flightCtx)
+			cancel()
+
+			g.mu.Lock()
+			g.forget(key, f)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody wants the result; new callers start afresh
+			f.cancel()
+			g.forget(key, f)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes f from the group unless a newer flight has replaced it.
+// The caller holds g.mu.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
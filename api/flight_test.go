@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"neongecko/config"
+)
+
+// blockingServer answers every request with {"id":"bitcoin"} once release
+// is closed, counting requests and noting any the client abandoned.
+type blockingServer struct {
+	*httptest.Server
+	requests  atomic.Int32
+	abandoned chan struct{}
+	release   chan struct{}
+}
+
+func newBlockingServer(t *testing.T) *blockingServer {
+	s := &blockingServer{abandoned: make(chan struct{}, 1), release: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		select {
+		case <-s.release:
+			w.Write([]byte(`{"id":"bitcoin"}`))
+		case <-r.Context().Done():
+			s.abandoned <- struct{}{}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newFlightClient(t *testing.T) *Client {
+	cfg := config.DefaultConfig
+	cfg.API.DiskCache = new(bool)
+	cfg.API.RateLimit = 0
+	client := NewClient(&cfg)
+	t.Cleanup(client.Close)
+	return client
+}
+
+// waitForWaiters blocks until n callers are waiting on the flight for url.
+func waitForWaiters(t *testing.T, client *Client, url string, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		client.flights.mu.Lock()
+		f := client.flights.flights[url]
+		joined := f != nil && f.waiters == n
+		client.flights.mu.Unlock()
+		if joined {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers to join the request", n)
+}
+
+func TestGetSharesConcurrentRequests(t *testing.T) {
+	server := newBlockingServer(t)
+	client := newFlightClient(t)
+	url := server.URL + "/coins/bitcoin"
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	ids := make([]string, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var v struct{ ID string }
+			errs[i] = client.get(context.Background(), url, "coin 'bitcoin'", &v)
+			ids[i] = v.ID
+		}()
+	}
+
+	waitForWaiters(t, client, url, callers)
+	close(server.release)
+	wg.Wait()
+
+	for i := range callers {
+		if errs[i] != nil || ids[i] != "bitcoin" {
+			t.Errorf("caller %d got %q, %v, want bitcoin", i, ids[i], errs[i])
+		}
+	}
+	if n := server.requests.Load(); n != 1 {
+		t.Errorf("server got %d requests, want 1", n)
+	}
+}
+
+func TestGetCancelledCallerLeavesOthersWaiting(t *testing.T) {
+	server := newBlockingServer(t)
+	client := newFlightClient(t)
+	url := server.URL + "/coins/bitcoin"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		var v struct{ ID string }
+		cancelled <- client.get(ctx, url, "coin 'bitcoin'", &v)
+	}()
+
+	waiting := make(chan error, 1)
+	var id string
+	go func() {
+		var v struct{ ID string }
+		err := client.get(context.Background(), url, "coin 'bitcoin'", &v)
+		id = v.ID
+		waiting <- err
+	}()
+
+	waitForWaiters(t, client, url, 2)
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+
+	close(server.release)
+	if err := <-waiting; err != nil || id != "bitcoin" {
+		t.Errorf("remaining caller got %q, %v, want bitcoin", id, err)
+	}
+	select {
+	case <-server.abandoned:
+		t.Error("the shared request was cancelled while a caller still waited on it")
+	default:
+	}
+}
+
+func TestGetLastCallerCancellingAbandonsRequest(t *testing.T) {
+	server := newBlockingServer(t)
+	client := newFlightClient(t)
+	url := server.URL + "/coins/bitcoin"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		var v struct{ ID string }
+		done <- client.get(ctx, url, "coin 'bitcoin'", &v)
+	}()
+
+	waitForWaiters(t, client, url, 1)
+	cancel()
+	<-done
+
+	select {
+	case <-server.abandoned:
+	case <-time.After(5 * time.Second):
+		t.Error("the shared request kept running after every caller gave up")
+	}
+}
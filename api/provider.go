@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"neongecko/models"
+)
+
+// Provider is a source of market data. Client is the CoinGecko-backed
+// implementation; a self-hosted price service or a fixture-backed provider
+// only needs to satisfy this interface to be used by the UI. Requests should
+// be abandoned as soon as ctx is cancelled. Prices are quoted in currency, a
+// CoinGecko vs_currency code such as "usd", "eur" or "btc".
+type Provider interface {
+	GetGlobalDataContext(ctx context.Context, currency string) (*models.GlobalData, error)
+	GetCoinDataContext(ctx context.Context, coinID string, currency string) (*models.Coin, error)
+	SearchCoinsContext(ctx context.Context, query string) ([]models.Coin, error)
+	GetPriceHistoryContext(ctx context.Context, coinID string, currency string, days string) (*models.PriceHistory, error)
+	GetOHLCContext(ctx context.Context, coinID string, currency string, days string) ([]models.Candle, error)
+	GetSupportedCurrenciesContext(ctx context.Context) ([]string, error)
+	GetMarketsContext(ctx context.Context, query MarketsQuery) ([]models.Coin, error)
+}
+
+// MaxPerPage is the most coins one markets request returns.
+const MaxPerPage = 250
+
+// MarketsQuery selects a page of coins with market data in one request.
+type MarketsQuery struct {
+	Currency string
+	IDs      []string // Only these coins; empty for all coins
+	Order    string   // CoinGecko order such as "market_cap_desc"; empty for the default
+	Page     int      // 1-based; 0 means the first page
+	PerPage  int      // Up to MaxPerPage; 0 means the API default
+}
+
+// RateLimitReporter is implemented by providers that throttle outgoing
+// requests, so the UI can show when it is waiting on the rate limit.
+type RateLimitReporter interface {
+	RateLimitPending() time.Duration
+}
+
+var (
+	_ Provider          = (*Client)(nil)
+	_ RateLimitReporter = (*Client)(nil)
+)
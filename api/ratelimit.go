@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request a Client makes. The
+// bucket holds one minute's worth of requests and refills evenly, so bursts
+// are allowed as long as the per-minute budget is respected.
+type RateLimiter struct {
+	mu        sync.Mutex
+	tokens    float64
+	capacity  float64
+	interval  time.Duration // Time to refill a single token
+	maxWait   time.Duration // Longest a request may queue before being rejected
+	last      time.Time
+	releaseAt time.Time // When the most recently queued request may proceed
+}
+
+// NewRateLimiter returns a limiter allowing perMinute requests per minute, or
+// nil (no limiting) when perMinute is not positive.
+func NewRateLimiter(perMinute int) *RateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+
+	return &RateLimiter{
+		tokens:   float64(perMinute),
+		capacity: float64(perMinute),
+		interval: time.Minute / time.Duration(perMinute),
+		maxWait:  time.Minute,
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until the caller may send a request or ctx is cancelled.
+// Requests queue in arrival order; a RateLimitError is returned instead of
+// queueing past maxWait.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay, err := l.reserve()
+	if err != nil {
+		return err
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// The request is never sent, so hand its token back
+		l.release()
+		return ctx.Err()
+	}
+}
+
+// Pending reports how long until every queued request has been released.
+// It is zero when nothing is waiting on the limiter.
+func (l *RateLimiter) Pending() time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pending := time.Until(l.releaseAt); pending > 0 {
+		return pending
+	}
+	return 0
+}
+
+func (l *RateLimiter) reserve() (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+
+	// Tokens may go negative: each missing token is one interval of queueing
+	delay := time.Duration(-(l.tokens - 1) * float64(l.interval))
+	if delay > l.maxWait {
+		return 0, &RateLimitError{ResetAt: now.Add(delay - l.maxWait)}
+	}
+
+	l.tokens--
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	l.releaseAt = now.Add(delay)
+	return delay, nil
+}
+
+// release hands back the token of a queued request that gave up, and the
+// interval it would have waited, so Pending doesn't count it.
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+
+	l.releaseAt = l.releaseAt.Add(-l.interval)
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	l.last = now
+
+	l.tokens += float64(elapsed) / float64(l.interval)
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterPendingAfterCancelledWait(t *testing.T) {
+	// One request per second, with the bucket already empty
+	limiter := NewRateLimiter(60)
+	limiter.tokens = 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if pending := limiter.Pending(); pending != 0 {
+		t.Errorf("Pending() = %v after the only waiter gave up, want 0", pending)
+	}
+}
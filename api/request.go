@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// get fetches url and decodes its JSON body into v. Concurrent gets of the
+// same url share one request, and each decodes its own copy of the result.
+// A 404 is reported as a NotFoundError for resource, which names what was
+// asked for in words rather than as an API path.
+func (c *Client) get(ctx context.Context, url string, resource string, v interface{}) error {
+	body, err := c.flights.Do(ctx, url, func(ctx context.Context) ([]byte, error) {
+		return c.getBody(ctx, url)
+	})
+	var notFoundErr *NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return &NotFoundError{Resource: resource}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+// getBody fetches url. Every attempt passes through the rate limiter; 429
+// and 5xx responses are retried with jittered exponential backoff, honoring
+// Retry-After when the server sends one. Failures are returned as the typed
+// errors in errors.go.
+func (c *Client) getBody(ctx context.Context, url string) ([]byte, error) {
+	maxRetries := c.config.GetMaxRetries()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		body, err := c.fetch(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+
+		if !retryable(err) || attempt >= maxRetries {
+			return nil, err
+		}
+
+		delay := c.backoff(attempt)
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter() > 0 {
+			delay = rateLimitErr.RetryAfter()
+		}
+
+		// Give up rather than stall the caller past the configured ceiling
+		if delay > c.config.GetRetryMaxBackoff() {
+			return nil, err
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// validated is a response body with the validators the server sent for it,
+// so a refresh can ask whether it changed instead of downloading it again.
+type validated struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+}
+
+// fetch sends one request for url. If an earlier response carried an ETag
+// or Last-Modified, the request is made conditional, and a 304 Not Modified
+// answers with that earlier body.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, conditional := c.validators.Get(url)
+	if conditional {
+		if previous.ETag != "" {
+			req.Header.Set("If-None-Match", previous.ETag)
+		}
+		if previous.LastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.LastModified)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &NetworkError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{Err: fmt.Errorf("failed to read response body: %w", err)}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		c.remember(url, validated{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		})
+		return body, nil
+	case http.StatusNotModified:
+		if !conditional {
+			return nil, &UpstreamError{StatusCode: resp.StatusCode, Body: bodySnippet(body)}
+		}
+
+		// A 304 may carry updated validators
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			previous.ETag = etag
+		}
+		if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+			previous.LastModified = lastModified
+		}
+		c.remember(url, previous)
+		return previous.Body, nil
+	case http.StatusNotFound:
+		return nil, &NotFoundError{Resource: resp.Request.URL.Path}
+	case http.StatusTooManyRequests:
+		rateLimitErr := &RateLimitError{}
+		if wait := parseRetryAfter(resp.Header.Get("Retry-After")); wait > 0 {
+			rateLimitErr.ResetAt = time.Now().Add(wait)
+		}
+		return nil, rateLimitErr
+	default:
+		return nil, &UpstreamError{StatusCode: resp.StatusCode, Body: bodySnippet(body)}
+	}
+}
+
+// remember keeps entry for conditional requests of url, or forgets url if
+// the server sent no validators.
+func (c *Client) remember(url string, entry validated) {
+	if entry.ETag == "" && entry.LastModified == "" {
+		c.validators.Delete(url)
+		return
+	}
+
+	// Validators are worth keeping as long as stale results are
+	c.validators.Set(url, entry, staleFor)
+}
+
+// retryable reports whether a failed request may succeed if sent again.
+func retryable(err error) bool {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return true
+	}
+
+	var upstreamErr *UpstreamError
+	return errors.As(err, &upstreamErr) && upstreamErr.StatusCode >= 500
+}
+
+// backoff returns the delay before retry number attempt+1: the base delay
+// doubled per attempt, capped, with jitter over the upper half of the range.
+func (c *Client) backoff(attempt int) time.Duration {
+	base := c.config.GetRetryBackoff()
+	ceiling := c.config.GetRetryMaxBackoff()
+
+	delay := base << attempt
+	if delay <= 0 || delay > ceiling {
+		delay = ceiling
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// sleep waits for d, returning early with the context's error if it is
+// cancelled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter accepts both forms of the Retry-After header: a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
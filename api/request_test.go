@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"neongecko/config"
+)
+
+func TestGetCoinDataNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"coin not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig
+	cfg.API.BaseURL = server.URL
+	cfg.API.DiskCache = new(bool)
+	client := NewClient(&cfg)
+	defer client.Close()
+
+	_, err := client.GetCoinDataContext(context.Background(), "xyz", "usd")
+
+	var notFoundErr *NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("GetCoinDataContext() error = %v, want a NotFoundError", err)
+	}
+	if want := "coin 'xyz'"; notFoundErr.Resource != want {
+		t.Errorf("NotFoundError.Resource = %q, want %q", notFoundErr.Resource, want)
+	}
+}
+
+func TestGetRevalidatesWithValidators(t *testing.T) {
+	const lastModified = "Wed, 01 May 2024 12:00:00 GMT"
+	var ifNoneMatch, ifModifiedSince []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastModified)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"id":"bitcoin"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig
+	cfg.API.DiskCache = new(bool)
+	client := NewClient(&cfg)
+	defer client.Close()
+	url := server.URL + "/coins/bitcoin"
+
+	get := func() string {
+		t.Helper()
+		var v struct{ ID string }
+		if err := client.get(context.Background(), url, "coin 'bitcoin'", &v); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+		return v.ID
+	}
+
+	if id := get(); id != "bitcoin" {
+		t.Fatalf("first get() = %q, want bitcoin", id)
+	}
+	if id := get(); id != "bitcoin" {
+		t.Errorf("get() answered with 304 = %q, want the remembered bitcoin", id)
+	}
+	if ifNoneMatch[0] != "" || ifModifiedSince[0] != "" {
+		t.Errorf("first request sent If-None-Match %q, If-Modified-Since %q, want neither", ifNoneMatch[0], ifModifiedSince[0])
+	}
+	if ifNoneMatch[1] != `"v1"` || ifModifiedSince[1] != lastModified {
+		t.Errorf("second request sent If-None-Match %q, If-Modified-Since %q, want %q and %q", ifNoneMatch[1], ifModifiedSince[1], `"v1"`, lastModified)
+	}
+
+	// Validators live as long as stale results, then requests are unconditional again
+	_, expiresAt, _ := client.validators.GetStale(url)
+	if until := time.Until(expiresAt); until < staleFor-time.Minute || until > staleFor {
+		t.Errorf("validators expire in %s, want %s", until, staleFor)
+	}
+	entry, _, _ := client.validators.GetStale(url)
+	client.validators.SetUntil(url, entry, time.Now().Add(-time.Second))
+	get()
+	if ifNoneMatch[2] != "" || ifModifiedSince[2] != "" {
+		t.Errorf("request after validators expired sent If-None-Match %q, If-Modified-Since %q, want neither", ifNoneMatch[2], ifModifiedSince[2])
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"neongecko/api"
+	"neongecko/config"
+)
+
+func runCache(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("cache", flag.ContinueOnError)
+	output := outputFlag(flags)
+	actions, err := parseFlags(flags, args)
+	if err != nil {
+		return flagError(err)
+	}
+	if len(actions) != 1 || (actions[0] != "clear" && actions[0] != "stats") {
+		fmt.Fprintln(os.Stderr, "Usage: neongecko cache clear|stats")
+		return exitUsage
+	}
+
+	dir, err := api.DefaultDiskCacheDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+	disk, err := api.NewDiskCache(dir, cfg.GetDiskCacheMaxBytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	if actions[0] == "clear" {
+		if err := disk.Clear(); err != nil {
+			fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+			return exitError
+		}
+		// The status command keeps its own cache file alongside
+		if path, err := statusCachePath(); err == nil {
+			os.Remove(path)
+		}
+		fmt.Println("Cache cleared")
+		return exitOK
+	}
+
+	stats, err := disk.Stats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	if err := writeRows(os.Stdout, *output, []api.DiskCacheStats{stats}, true, func(w io.Writer) error {
+		enabled := "yes"
+		if !cfg.GetDiskCache() {
+			enabled = "no (api.disk_cache is off)"
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "Enabled\t%s\n", enabled)
+		fmt.Fprintf(tw, "Directory\t%s\n", stats.Dir)
+		fmt.Fprintf(tw, "Entries\t%d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Fprintf(tw, "Size\t%s of %s\n", formatBytes(stats.Bytes), formatBytes(stats.MaxBytes))
+		return tw.Flush()
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"neongecko/config"
+)
+
+// Exit codes returned by Run.
+const (
+	exitOK       = 0
+	exitError    = 1 // The command failed, e.g. an API or file error
+	exitUsage    = 2 // Unknown command or bad flags
+	exitNotFound = 3 // A requested coin doesn't exist
+)
+
+// command is a headless subcommand, run instead of the TUI.
+type command struct {
+	name    string
+	summary string
+	run     func(cfg *config.Config, args []string) int
+}
+
+var commands []command
+
+func init() {
+	// Assigned here because the help command lists them all
+	commands = []command{
+		{name: "price", summary: "Print current prices of coins, e.g. 'price bitcoin ethereum'", run: runPrice},
+		{name: "global", summary: "Print global market statistics", run: runGlobal},
+		{name: "status", summary: "Print favorites on one line for status bars (tmux, polybar, starship)", run: runStatus},
+		{name: "watch", summary: "Print a row of prices every interval, e.g. 'watch bitcoin --interval 30s'", run: runWatch},
+		{name: "cache", summary: "Manage the on-disk API cache: 'cache stats' or 'cache clear'", run: runCache},
+		{name: "daemon", summary: "Check alert rules in the background and dispatch fired alerts", run: runDaemon},
+		{name: "help", summary: "Show this help", run: runHelp},
+	}
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit
+// code.
+func Run(args []string) int {
+	name := args[0]
+	if name == "-h" || name == "--help" {
+		name = "help"
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(loadConfig(), args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "neongecko: unknown command %q\n\n", args[0])
+	usage(os.Stderr)
+	return exitUsage
+}
+
+func loadConfig() *config.Config {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("Warning: Failed to load config, using defaults: %v", err)
+		cfg = &config.DefaultConfig
+	}
+	return cfg
+}
+
+// parseFlags parses args with flags allowed before, between and after the
+// positional arguments, which it returns in order.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// flagError maps a flag parsing error to an exit code; asking for help is
+// not a failure.
+func flagError(err error) int {
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	return exitUsage
+}
+
+// commandContext is cancelled when the process is interrupted or
+// terminated.
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func runHelp(cfg *config.Config, args []string) int {
+	usage(os.Stdout)
+	return exitOK
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: neongecko [command] [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Without a command, neongecko starts the interactive TUI.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'neongecko <command> -h' for the flags of a command.")
+}
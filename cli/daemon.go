@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"neongecko/alerts"
+	"neongecko/api"
+	"neongecko/config"
+)
+
+// sinkTimeout bounds each alert delivery, so a sink that never answers
+// can't stall polling.
+const sinkTimeout = 30 * time.Second
+
+func runDaemon(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	interval := flags.Duration("interval", cfg.GetAlertPollInterval(), "time between checks")
+	command := flags.String("command", cfg.Alerts.Command, "shell command run per alert, with the alert as JSON on stdin")
+	webhook := flags.String("webhook", cfg.Alerts.Webhook, "URL each alert is POSTed to as JSON")
+	once := flags.Bool("once", false, "check the rules once and exit")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if *interval <= 0 {
+		log.Printf("Invalid interval %s", *interval)
+		return exitUsage
+	}
+
+	// Each poll should see fresh prices rather than the TUI's cache lifetime
+	daemonCfg := *cfg
+	daemonCfg.API.CacheTTL = (*interval / 2).String()
+	client := api.NewClient(&daemonCfg)
+	defer client.Close()
+
+	var sinks []alerts.Sink
+	if *output == outputTable {
+		sinks = append(sinks, alerts.WriterSink{W: os.Stdout})
+	} else {
+		sinks = append(sinks, &eventSink{w: os.Stdout, format: *output})
+	}
+	if *command != "" {
+		sinks = append(sinks, alerts.CommandSink{Command: *command})
+	}
+	if *webhook != "" {
+		sinks = append(sinks, alerts.WebhookSink{
+			URL:    *webhook,
+			Client: &http.Client{Timeout: cfg.GetTimeout()},
+		})
+	}
+
+	ctx, stop := commandContext()
+	defer stop()
+
+	log.Printf("Checking alerts every %s", *interval)
+	for {
+		requests, err := poll(ctx, client, sinks)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Alert check failed: %v", err)
+			if *once {
+				return exitError
+			}
+		}
+		if *once {
+			return exitOK
+		}
+
+		wait := *interval
+		if floor := pollFloor(requests, cfg.API.RateLimit); wait < floor {
+			// Polling faster would only queue up behind the rate limiter
+			log.Printf("Waiting %s instead of %s to stay within the rate limit", floor, wait)
+			wait = floor
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Stopping")
+			return exitOK
+		case <-time.After(wait):
+		}
+	}
+}
+
+// poll checks every stored rule once and sends fired alerts to sinks. It
+// reloads the rules each time so edits made in the TUI are picked up, and
+// returns how many API requests the check needed.
+func poll(ctx context.Context, provider api.Provider, sinks []alerts.Sink) (int, error) {
+	rules, err := alerts.LoadRules()
+	if err != nil {
+		return 0, err
+	}
+	if len(rules.Rules) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	checks, err := alerts.Evaluate(ctx, provider, rules.Rules, now)
+	if err != nil {
+		return requestsFor(rules.Rules), err
+	}
+	for _, check := range checks {
+		if check.Err != nil {
+			log.Printf("Failed to check %s: %v", check.Rule, check.Err)
+		}
+	}
+
+	fired := rules.Apply(checks, now)
+	if err := alerts.SaveRules(rules); err != nil {
+		return requestsFor(rules.Rules), err
+	}
+
+	for _, check := range fired {
+		event := alerts.NewEvent(check, now)
+		for _, sink := range sinks {
+			sendCtx, cancel := context.WithTimeout(ctx, sinkTimeout)
+			err := sink.Send(sendCtx, event)
+			cancel()
+			if err != nil {
+				log.Printf("Failed to deliver alert %s: %v", event.RuleID, err)
+			}
+		}
+	}
+
+	return requestsFor(rules.Rules), nil
+}
+
+// requestsFor estimates the API requests one check of rules makes: one
+// markets request per currency plus a history request per crossing rule.
+func requestsFor(rules []alerts.Rule) int {
+	currencies := make(map[string]bool)
+	requests := 0
+	for _, rule := range rules {
+		if !currencies[rule.Currency] {
+			currencies[rule.Currency] = true
+			requests++
+		}
+		switch rule.Condition {
+		case alerts.CrossesAbove, alerts.CrossesBelow, alerts.Crosses:
+			requests++
+		}
+	}
+	return requests
+}
+
+// pollFloor is the shortest interval that keeps requests per poll within
+// perMinute. A limit of zero or less means no limit.
+func pollFloor(requests, perMinute int) time.Duration {
+	if perMinute <= 0 {
+		return 0
+	}
+	return time.Duration(requests) * time.Minute / time.Duration(perMinute)
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/models"
+)
+
+func runGlobal(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("global", flag.ContinueOnError)
+	currency := flags.String("currency", cfg.GetCurrency(), "currency to report in, e.g. usd or eur")
+	output := outputFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	ctx, stop := commandContext()
+	defer stop()
+
+	client := api.NewClient(cfg)
+	defer client.Close()
+	data, err := client.GetGlobalDataContext(ctx, strings.ToLower(*currency))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	if err := writeRows(os.Stdout, *output, []models.GlobalData{*data}, true, func(w io.Writer) error {
+		return globalTable(w, data)
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func globalTable(w io.Writer, data *models.GlobalData) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Market cap\t%s %s\n", formatPrice(data.TotalMarketCap), data.Currency)
+	fmt.Fprintf(tw, "24h volume\t%s %s\n", formatPrice(data.TotalVolume), data.Currency)
+	fmt.Fprintf(tw, "24h change\t%s\n", formatChange(data.MarketCapChangePercentage24h))
+	return tw.Flush()
+}
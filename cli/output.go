@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"neongecko/alerts"
+)
+
+// outputFormat is how a command writes its results.
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table" // Aligned for people to read
+	outputJSON  outputFormat = "json"
+	outputCSV   outputFormat = "csv"
+	outputTSV   outputFormat = "tsv"
+)
+
+var outputFormats = []outputFormat{outputTable, outputJSON, outputCSV, outputTSV}
+
+// outputFlag registers --output on flags.
+func outputFlag(flags *flag.FlagSet) *outputFormat {
+	format := outputTable
+	flags.Func("output", "output format: table, json, csv or tsv (default table)", func(value string) error {
+		for _, f := range outputFormats {
+			if outputFormat(strings.ToLower(value)) == f {
+				format = f
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown format %q", value)
+	})
+	return &format
+}
+
+// writeRows writes rows in format. JSON is an array of objects, or a single
+// object when single is set; CSV and TSV have a header row. Both use the
+// rows' json tags for names, so every format agrees. table renders the
+// human-readable form.
+func writeRows[T any](w io.Writer, format outputFormat, rows []T, single bool, table func(w io.Writer) error) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if single && len(rows) == 1 {
+			return encoder.Encode(rows[0])
+		}
+		if rows == nil {
+			rows = []T{}
+		}
+		return encoder.Encode(rows)
+
+	case outputCSV, outputTSV:
+		writer := newRecordWriter(w, format)
+		if err := writer.header(reflect.TypeFor[T]()); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := writer.row(row); err != nil {
+				return err
+			}
+		}
+		return writer.flush()
+	}
+
+	return table(w)
+}
+
+// recordWriter writes structs as CSV or TSV records, one column per json
+// tagged field.
+type recordWriter struct {
+	writer *csv.Writer
+}
+
+func newRecordWriter(w io.Writer, format outputFormat) recordWriter {
+	writer := csv.NewWriter(w)
+	if format == outputTSV {
+		writer.Comma = '\t'
+	}
+	return recordWriter{writer: writer}
+}
+
+func (r recordWriter) header(t reflect.Type) error {
+	var names []string
+	for _, field := range columns(t) {
+		names = append(names, field.name)
+	}
+	return r.writer.Write(names)
+}
+
+func (r recordWriter) row(v any) error {
+	value := reflect.ValueOf(v)
+	var record []string
+	for _, field := range columns(value.Type()) {
+		record = append(record, formatField(value.FieldByIndex(field.index)))
+	}
+	return r.writer.Write(record)
+}
+
+func (r recordWriter) flush() error {
+	r.writer.Flush()
+	return r.writer.Error()
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+// columns lists the json tagged fields of struct type t, flattening
+// embedded structs the way encoding/json does.
+func columns(t reflect.Type) []column {
+	var result []column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for _, embedded := range columns(field.Type) {
+				result = append(result, column{name: embedded.name, index: append([]int{i}, embedded.index...)})
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		result = append(result, column{name: name, index: []int{i}})
+	}
+	return result
+}
+
+// formatField writes one value as a plain cell: numbers in full precision,
+// times as RFC 3339 and nil or zero times as empty.
+func formatField(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.String:
+		return value.String()
+	case reflect.Slice:
+		var items []string
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, formatField(value.Index(i)))
+		}
+		return strings.Join(items, ";")
+	}
+
+	data, _ := json.Marshal(value.Interface())
+	return string(data)
+}
+
+// eventSink writes fired alerts to w in a machine-readable format: JSON
+// lines, or CSV/TSV with the header written before the first alert.
+type eventSink struct {
+	w       io.Writer
+	format  outputFormat
+	mu      sync.Mutex
+	started bool
+}
+
+func (s *eventSink) Send(ctx context.Context, event alerts.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.format == outputJSON {
+		return json.NewEncoder(s.w).Encode(event)
+	}
+
+	writer := newRecordWriter(s.w, s.format)
+	if !s.started {
+		if err := writer.header(reflect.TypeFor[alerts.Event]()); err != nil {
+			return err
+		}
+		s.started = true
+	}
+	if err := writer.row(event); err != nil {
+		return err
+	}
+	return writer.flush()
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/models"
+)
+
+func runPrice(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("price", flag.ContinueOnError)
+	currency := flags.String("currency", cfg.GetCurrency(), "currency to price in, e.g. usd or eur")
+	output := outputFlag(flags)
+	coinIDs, err := parseFlags(flags, args)
+	if err != nil {
+		return flagError(err)
+	}
+	if len(coinIDs) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: neongecko price <coin-id>... [--currency eur]")
+		return exitUsage
+	}
+	for i, coinID := range coinIDs {
+		coinIDs[i] = strings.ToLower(coinID)
+	}
+
+	ctx, stop := commandContext()
+	defer stop()
+
+	client := api.NewClient(cfg)
+	defer client.Close()
+	coins, err := client.GetMarketsContext(ctx, api.MarketsQuery{
+		Currency: strings.ToLower(*currency),
+		IDs:      coinIDs,
+		PerPage:  len(coinIDs),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	// Print in the order asked for, reporting coins CoinGecko doesn't know
+	byID := make(map[string]models.Coin)
+	for _, coin := range coins {
+		byID[coin.ID] = coin
+	}
+
+	var found []models.Coin
+	code := exitOK
+	for _, coinID := range coinIDs {
+		coin, ok := byID[coinID]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "neongecko: unknown coin %q\n", coinID)
+			code = exitNotFound
+			continue
+		}
+		found = append(found, coin)
+	}
+
+	if err := writeRows(os.Stdout, *output, found, false, func(w io.Writer) error {
+		return priceTable(w, found)
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	return code
+}
+
+// priceTable prints a single coin as a compact line and several as a table.
+func priceTable(w io.Writer, coins []models.Coin) error {
+	if len(coins) == 1 {
+		coin := coins[0]
+		_, err := fmt.Fprintf(w, "%s %s %s %s\n", coin.ID, formatPrice(coin.CurrentPrice), coin.Currency, formatChange(coin.PriceChangePercentage24h))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSYMBOL\tPRICE\tCURRENCY\t24H")
+	for _, coin := range coins {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", coin.ID, strings.ToUpper(coin.Symbol), formatPrice(coin.CurrentPrice), coin.Currency, formatChange(coin.PriceChangePercentage24h))
+	}
+	return tw.Flush()
+}
+
+// formatPrice writes a price in full, with no grouping or abbreviation, so
+// scripts can parse it.
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+func formatChange(change float64) string {
+	return fmt.Sprintf("%+.2f%%", change)
+}
@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/models"
+	"neongecko/ui"
+)
+
+// statusCoin is what a status template sees for each coin.
+type statusCoin struct {
+	ID        string
+	Symbol    string // Upper case, e.g. BTC
+	Name      string
+	Currency  string
+	Price     string // Formatted, e.g. $64.12K
+	Change24h string // Signed and colored, e.g. +1.23%
+	Change7d  string
+	Up        bool // 24h change is positive
+	Coin      models.Coin
+}
+
+// statusColors wrap text in terminal or tmux color codes.
+var statusColors = map[string]struct{ up, down, reset string }{
+	"none": {},
+	"ansi": {up: "\x1b[32m", down: "\x1b[31m", reset: "\x1b[0m"},
+	"tmux": {up: "#[fg=green]", down: "#[fg=red]", reset: "#[default]"},
+}
+
+func runStatus(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	format := flags.String("template", cfg.GetStatusTemplate(), "text/template rendered per coin")
+	separator := flags.String("separator", cfg.GetStatusSeparator(), "text between coins")
+	color := flags.String("color", cfg.Status.Color, "color codes for changes: none, ansi or tmux")
+	currency := flags.String("currency", cfg.GetCurrency(), "currency to price in, e.g. usd or eur")
+	output := outputFlag(flags)
+	coinIDs, err := parseFlags(flags, args)
+	if err != nil {
+		return flagError(err)
+	}
+	if len(coinIDs) == 0 {
+		coinIDs = cfg.Display.Favorites
+	}
+	if len(coinIDs) == 0 {
+		fmt.Fprintln(os.Stderr, "neongecko: no favorites; pass coin IDs or add favorites in the TUI")
+		return exitUsage
+	}
+
+	colors, ok := statusColors[strings.ToLower(*color)]
+	if *color != "" && !ok {
+		fmt.Fprintf(os.Stderr, "neongecko: unknown color mode %q, expected none, ansi or tmux\n", *color)
+		return exitUsage
+	}
+
+	tmpl, err := template.New("status").Parse(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: invalid template: %v\n", err)
+		return exitUsage
+	}
+
+	ctx, stop := commandContext()
+	defer stop()
+
+	coins, err := statusCoins(ctx, cfg, strings.ToLower(*currency), coinIDs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+
+	// The template only shapes the status line; other formats get the coins
+	var line string
+	if *output == outputTable {
+		if line, err = statusLine(tmpl, colors, *separator, coins); err != nil {
+			fmt.Fprintf(os.Stderr, "neongecko: template failed: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	if err := writeRows(os.Stdout, *output, coins, false, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, line)
+		return err
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// statusLine renders tmpl for each coin, joined by separator.
+func statusLine(tmpl *template.Template, colors struct{ up, down, reset string }, separator string, coins []models.Coin) (string, error) {
+	var parts []string
+	for _, coin := range coins {
+		change := func(value float64) string {
+			switch {
+			case value > 0:
+				return colors.up + formatChange(value) + colors.reset
+			case value < 0:
+				return colors.down + formatChange(value) + colors.reset
+			}
+			return formatChange(value)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, statusCoin{
+			ID:        coin.ID,
+			Symbol:    strings.ToUpper(coin.Symbol),
+			Name:      coin.Name,
+			Currency:  coin.Currency,
+			Price:     ui.FormatCurrency(coin.CurrentPrice, coin.Currency),
+			Change24h: change(coin.PriceChangePercentage24h),
+			Change7d:  change(coin.PriceChangePercentage7d),
+			Up:        coin.PriceChangePercentage24h > 0,
+			Coin:      coin,
+		}); err != nil {
+			return "", err
+		}
+		parts = append(parts, buf.String())
+	}
+
+	return strings.Join(parts, separator), nil
+}
+
+// statusCache is the last fetch of the status command, kept on disk so a
+// status bar calling every few seconds shares one request.
+type statusCache struct {
+	FetchedAt time.Time     `json:"fetched_at"`
+	Currency  string        `json:"currency"`
+	CoinIDs   []string      `json:"coin_ids"`
+	Coins     []models.Coin `json:"coins"`
+}
+
+// statusCoins returns the coins from the on-disk cache while it is fresh,
+// otherwise from the API. The cache lifetime is never shorter than the
+// rate limit allows for one request, and a stale cache is used if the API
+// fails.
+func statusCoins(ctx context.Context, cfg *config.Config, currency string, coinIDs []string) ([]models.Coin, error) {
+	ttl := cfg.GetStatusCacheTTL()
+	if cfg.API.RateLimit > 0 {
+		ttl = max(ttl, time.Minute/time.Duration(cfg.API.RateLimit))
+	}
+
+	cached, cacheErr := loadStatusCache()
+	matches := cacheErr == nil && cached.Currency == currency && slices.Equal(cached.CoinIDs, coinIDs)
+	if matches && time.Since(cached.FetchedAt) < ttl {
+		return cached.Coins, nil
+	}
+
+	client := api.NewClient(cfg)
+	defer client.Close()
+	coins, err := client.GetMarketsContext(ctx, api.MarketsQuery{
+		Currency: currency,
+		IDs:      coinIDs,
+		PerPage:  len(coinIDs),
+	})
+	if err != nil {
+		if matches {
+			return cached.Coins, nil
+		}
+		return nil, err
+	}
+
+	// Keep the order the coins were asked for
+	ordered := make([]models.Coin, 0, len(coins))
+	for _, coinID := range coinIDs {
+		for _, coin := range coins {
+			if coin.ID == coinID {
+				ordered = append(ordered, coin)
+			}
+		}
+	}
+
+	saveStatusCache(statusCache{
+		FetchedAt: time.Now(),
+		Currency:  currency,
+		CoinIDs:   coinIDs,
+		Coins:     ordered,
+	})
+	return ordered, nil
+}
+
+func statusCachePath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+
+	dir := filepath.Join(cacheDir, "neongecko")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return filepath.Join(dir, "status.json"), nil
+}
+
+func loadStatusCache() (statusCache, error) {
+	var cached statusCache
+
+	path, err := statusCachePath()
+	if err != nil {
+		return cached, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cached, err
+	}
+
+	err = json.Unmarshal(data, &cached)
+	return cached, err
+}
+
+// saveStatusCache writes the cache through a temporary file so concurrent
+// status calls never read half of it. Failing to cache isn't worth failing
+// the command over.
+func saveStatusCache(cached statusCache) {
+	path, err := statusCachePath()
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "status-*.json")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	os.Rename(tmp.Name(), path)
+}
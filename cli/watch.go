@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/models"
+)
+
+// watchRow is one coin at one tick of the watch command.
+type watchRow struct {
+	Time      time.Time `json:"time"`
+	ID        string    `json:"id"`
+	Price     float64   `json:"price"`
+	Currency  string    `json:"currency"`
+	Delta     float64   `json:"delta"` // Price change since the previous tick
+	Change24h float64   `json:"change_24h"`
+}
+
+func runWatch(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := flags.Duration("interval", 30*time.Second, "time between updates")
+	currency := flags.String("currency", cfg.GetCurrency(), "currency to price in, e.g. usd or eur")
+	output := outputFlag(flags)
+	coinIDs, err := parseFlags(flags, args)
+	if err != nil {
+		return flagError(err)
+	}
+	if len(coinIDs) == 0 {
+		coinIDs = cfg.Display.Favorites
+	}
+	if len(coinIDs) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: neongecko watch <coin-id>... [--interval 30s]")
+		return exitUsage
+	}
+	if *interval <= 0 {
+		fmt.Fprintf(os.Stderr, "neongecko: invalid interval %s\n", *interval)
+		return exitUsage
+	}
+	for i, coinID := range coinIDs {
+		coinIDs[i] = strings.ToLower(coinID)
+	}
+
+	if floor := pollFloor(1, cfg.API.RateLimit); *interval < floor {
+		fmt.Fprintf(os.Stderr, "neongecko: using an interval of %s to stay within the rate limit\n", floor)
+		*interval = floor
+	}
+
+	// Cache for less than a tick so every tick sees a fresh price
+	watchCfg := *cfg
+	watchCfg.API.CacheTTL = (*interval / 2).String()
+	client := api.NewClient(&watchCfg)
+	defer client.Close()
+
+	ctx, stop := commandContext()
+	defer stop()
+
+	query := api.MarketsQuery{
+		Currency: strings.ToLower(*currency),
+		IDs:      coinIDs,
+		PerPage:  len(coinIDs),
+	}
+	writer := newWatchWriter(os.Stdout, *output)
+	previous := make(map[string]float64)
+	warned := false
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		coins, err := client.GetMarketsContext(ctx, query)
+		now := time.Now()
+		switch {
+		case ctx.Err() != nil:
+			return exitOK
+		case err != nil:
+			// Keep watching; the next tick may well succeed
+			fmt.Fprintf(os.Stderr, "%s neongecko: %v\n", now.Format(time.RFC3339), err)
+		default:
+			if !warned && len(coins) < len(coinIDs) {
+				warned = true
+				warnUnknown(coinIDs, coins)
+			}
+
+			for _, coinID := range coinIDs {
+				for _, coin := range coins {
+					if coin.ID != coinID {
+						continue
+					}
+
+					row := watchRow{
+						Time:      now,
+						ID:        coin.ID,
+						Price:     coin.CurrentPrice,
+						Currency:  coin.Currency,
+						Change24h: coin.PriceChangePercentage24h,
+					}
+					if last, ok := previous[coin.ID]; ok {
+						row.Delta = coin.CurrentPrice - last
+					}
+					previous[coin.ID] = coin.CurrentPrice
+
+					if err := writer.write(row); err != nil {
+						fmt.Fprintf(os.Stderr, "neongecko: %v\n", err)
+						return exitError
+					}
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-ticker.C:
+		}
+	}
+}
+
+// warnUnknown reports the requested coins CoinGecko didn't return.
+func warnUnknown(coinIDs []string, coins []models.Coin) {
+	for _, coinID := range coinIDs {
+		if !slices.ContainsFunc(coins, func(coin models.Coin) bool { return coin.ID == coinID }) {
+			fmt.Fprintf(os.Stderr, "neongecko: unknown coin %q\n", coinID)
+		}
+	}
+}
+
+// watchWriter streams rows as they arrive: a header once, then one line per
+// row, flushed immediately so output can be tailed or logged.
+type watchWriter struct {
+	w       io.Writer
+	format  outputFormat
+	started bool
+}
+
+func newWatchWriter(w io.Writer, format outputFormat) *watchWriter {
+	return &watchWriter{w: w, format: format}
+}
+
+func (s *watchWriter) write(row watchRow) error {
+	switch s.format {
+	case outputJSON:
+		// One object per line, so each tick can be parsed on its own
+		return json.NewEncoder(s.w).Encode(row)
+
+	case outputCSV, outputTSV:
+		writer := newRecordWriter(s.w, s.format)
+		if !s.started {
+			if err := writer.header(reflect.TypeFor[watchRow]()); err != nil {
+				return err
+			}
+			s.started = true
+		}
+		if err := writer.row(row); err != nil {
+			return err
+		}
+		return writer.flush()
+	}
+
+	if !s.started {
+		s.started = true
+		if _, err := fmt.Fprintf(s.w, "%-20s  %-16s  %16s  %14s  %8s\n", "TIME", "ID", "PRICE", "DELTA", "24H"); err != nil {
+			return err
+		}
+	}
+	delta := "+" + formatPrice(roundAmount(row.Delta))
+	if row.Delta < 0 {
+		delta = "-" + formatPrice(roundAmount(-row.Delta))
+	}
+	_, err := fmt.Fprintf(s.w, "%-20s  %-16s  %16s  %14s  %8s\n",
+		row.Time.Format("2006-01-02 15:04:05"), row.ID, formatPrice(row.Price)+" "+row.Currency, delta, formatChange(row.Change24h))
+	return err
+}
+
+// roundAmount drops floating point noise, such as the tail of a delta
+// between two prices, beyond eight decimals.
+func roundAmount(value float64) float64 {
+	return math.Round(value*1e8) / 1e8
+}
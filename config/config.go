@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -22,6 +23,14 @@ type Config struct {
 		CacheTTL    string `json:"cache_ttl"`    // Duration string like "5m"
 		Timeout     string `json:"timeout"`      // Duration string like "10s"
 		RateLimit   int    `json:"rate_limit"`   // Requests per minute
+		BaseURL     string `json:"base_url"`     // CoinGecko-compatible API root, "" for the public API
+		MaxRetries  int    `json:"max_retries"`  // Retries for 429/5xx responses, -1 to disable
+		RetryBackoff    string `json:"retry_backoff"`     // Initial backoff like "500ms", doubled per retry
+		RetryMaxBackoff string `json:"retry_max_backoff"` // Longest single wait, including Retry-After
+		DiskCache       *bool  `json:"disk_cache"`        // Keep responses on disk across restarts; on unless set to false
+		DiskCacheMaxMB  int    `json:"disk_cache_max_mb"` // Size cap of the disk cache
+		SearchCacheTTL  string `json:"search_cache_ttl"`  // How long search results and the currency list are reused, like "6h"
+		CacheMaxEntries int    `json:"cache_max_entries"` // Size cap of the memory cache, per kind of request
 	} `json:"api"`
 	
 	Display struct {
@@ -30,6 +39,19 @@ type Config struct {
 		ShowHelp       bool     `json:"show_help"`       // Show help on startup
 		Favorites      []string `json:"favorites"`       // List of favorite coin IDs
 	} `json:"display"`
+	
+	Alerts struct {
+		PollInterval string `json:"poll_interval"` // How often the daemon checks rules, like "5m"
+		Command      string `json:"command"`       // Shell command run per alert, with the alert as JSON on stdin
+		Webhook      string `json:"webhook"`       // URL each alert is POSTed to as JSON
+	} `json:"alerts"`
+	
+	Status struct {
+		Template  string `json:"template"`  // text/template rendered per coin by `neongecko status`
+		Separator string `json:"separator"` // Between coins
+		Color     string `json:"color"`     // "none", "ansi" or "tmux"
+		CacheTTL  string `json:"cache_ttl"` // How long fetched prices are reused, like "60s"
+	} `json:"status"`
 }
 
 var DefaultConfig = Config{
@@ -56,10 +78,26 @@ var DefaultConfig = Config{
 		CacheTTL    string `json:"cache_ttl"`
 		Timeout     string `json:"timeout"`
 		RateLimit   int    `json:"rate_limit"`
+		BaseURL     string `json:"base_url"`
+		MaxRetries  int    `json:"max_retries"`
+		RetryBackoff    string `json:"retry_backoff"`
+		RetryMaxBackoff string `json:"retry_max_backoff"`
+		DiskCache       *bool  `json:"disk_cache"`
+		DiskCacheMaxMB  int    `json:"disk_cache_max_mb"`
+		SearchCacheTTL  string `json:"search_cache_ttl"`
+		CacheMaxEntries int    `json:"cache_max_entries"`
 	}{
 		CacheTTL:  "5m",
 		Timeout:   "10s",
 		RateLimit: 30, // 30 requests per minute
+		BaseURL:   "", // Public CoinGecko API
+		MaxRetries:      3,
+		RetryBackoff:    "500ms",
+		RetryMaxBackoff: "60s",
+		DiskCache:       boolPtr(true),
+		DiskCacheMaxMB:  50,
+		SearchCacheTTL:  "6h",
+		CacheMaxEntries: 500,
 	},
 	Display: struct {
 		Currency       string   `json:"currency"`
@@ -72,6 +110,26 @@ var DefaultConfig = Config{
 		ShowHelp:      false,
 		Favorites:     []string{"bitcoin", "ethereum"},
 	},
+	Alerts: struct {
+		PollInterval string `json:"poll_interval"`
+		Command      string `json:"command"`
+		Webhook      string `json:"webhook"`
+	}{
+		PollInterval: "5m",
+		Command:      "", // No command hook
+		Webhook:      "", // No webhook
+	},
+	Status: struct {
+		Template  string `json:"template"`
+		Separator string `json:"separator"`
+		Color     string `json:"color"`
+		CacheTTL  string `json:"cache_ttl"`
+	}{
+		Template:  "{{.Symbol}} {{.Price}} {{.Change24h}}",
+		Separator: " | ",
+		Color:     "none",
+		CacheTTL:  "60s",
+	},
 }
 
 func GetConfigPath() (string, error) {
@@ -141,6 +199,24 @@ func (c *Config) GetCacheTTL() time.Duration {
 	return duration
 }
 
+// GetSearchCacheTTL is how long results that rarely change, like searches
+// and the supported currencies, are cached. It is never shorter than
+// GetCacheTTL.
+func (c *Config) GetSearchCacheTTL() time.Duration {
+	duration, err := time.ParseDuration(c.API.SearchCacheTTL)
+	if err != nil {
+		duration = 6 * time.Hour // Default fallback
+	}
+	return max(duration, c.GetCacheTTL())
+}
+
+func (c *Config) GetCacheMaxEntries() int {
+	if c.API.CacheMaxEntries <= 0 {
+		return 500 // Default fallback
+	}
+	return c.API.CacheMaxEntries
+}
+
 func (c *Config) GetTimeout() time.Duration {
 	duration, err := time.ParseDuration(c.API.Timeout)
 	if err != nil {
@@ -149,6 +225,85 @@ func (c *Config) GetTimeout() time.Duration {
 	return duration
 }
 
+func (c *Config) GetMaxRetries() int {
+	if c.API.MaxRetries < 0 {
+		return 0 // Retries disabled
+	}
+	if c.API.MaxRetries == 0 {
+		return 3 // Default when unset
+	}
+	return c.API.MaxRetries
+}
+
+func (c *Config) GetRetryBackoff() time.Duration {
+	duration, err := time.ParseDuration(c.API.RetryBackoff)
+	if err != nil || duration <= 0 {
+		return 500 * time.Millisecond // Default fallback
+	}
+	return duration
+}
+
+func (c *Config) GetRetryMaxBackoff() time.Duration {
+	duration, err := time.ParseDuration(c.API.RetryMaxBackoff)
+	if err != nil || duration <= 0 {
+		return 60 * time.Second // Default fallback
+	}
+	return duration
+}
+
+// GetDiskCache reports whether responses are kept on disk. Configs written
+// before the disk cache existed have no disk_cache key, and get it too.
+func (c *Config) GetDiskCache() bool {
+	return c.API.DiskCache == nil || *c.API.DiskCache
+}
+
+func (c *Config) GetDiskCacheMaxBytes() int64 {
+	if c.API.DiskCacheMaxMB <= 0 {
+		return 50 << 20 // Default fallback
+	}
+	return int64(c.API.DiskCacheMaxMB) << 20
+}
+
+// GetCurrency returns the configured vs_currency, normalized to the lower
+// case codes CoinGecko expects.
+func (c *Config) GetCurrency() string {
+	currency := strings.ToLower(strings.TrimSpace(c.Display.Currency))
+	if currency == "" {
+		return "usd" // Default fallback
+	}
+	return currency
+}
+
+func (c *Config) GetStatusTemplate() string {
+	if c.Status.Template == "" {
+		return DefaultConfig.Status.Template
+	}
+	return c.Status.Template
+}
+
+func (c *Config) GetStatusSeparator() string {
+	if c.Status.Separator == "" {
+		return DefaultConfig.Status.Separator
+	}
+	return c.Status.Separator
+}
+
+func (c *Config) GetStatusCacheTTL() time.Duration {
+	duration, err := time.ParseDuration(c.Status.CacheTTL)
+	if err != nil || duration < 0 {
+		return 60 * time.Second // Default fallback
+	}
+	return duration
+}
+
+func (c *Config) GetAlertPollInterval() time.Duration {
+	duration, err := time.ParseDuration(c.Alerts.PollInterval)
+	if err != nil || duration <= 0 {
+		return 5 * time.Minute // Default fallback
+	}
+	return duration
+}
+
 func (c *Config) IsFavorite(coinID string) bool {
 	for _, fav := range c.Display.Favorites {
 		if fav == coinID {
@@ -171,4 +326,8 @@ func (c *Config) RemoveFavorite(coinID string) {
 			break
 		}
 	}
-}
\ No newline at end of file
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
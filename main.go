@@ -2,9 +2,13 @@ package main
 
 import (
 	"log"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
+	"neongecko/api"
+	"neongecko/cli"
 	"neongecko/config"
 	"neongecko/ui"
 )
@@ -14,12 +18,21 @@ type view int
 const (
 	homeView view = iota
 	coinView
+	watchlistView
+	marketsView
+	portfolioView
+	alertsView
 )
 
 type mainModel struct {
 	currentView view
 	homeModel   ui.HomeModel
 	coinModel   ui.CoinModel
+	watchlist   ui.WatchlistModel
+	markets     ui.MarketsModel
+	portfolio   ui.PortfolioModel
+	alerts      ui.AlertsModel
+	client      api.Provider
 	config      *config.Config
 	width       int
 	height      int
@@ -33,16 +46,27 @@ func initialModel() mainModel {
 		cfg = &config.DefaultConfig
 	}
 
+	// One provider shared by every view; the TUI shows stale data with a
+	// badge rather than block on a refresh
+	client := api.NewClient(cfg)
+	client.ServeStale()
+
 	return mainModel{
 		currentView: homeView,
-		homeModel:   ui.NewHomeModel(cfg),
-		coinModel:   ui.NewCoinModel(cfg),
+		homeModel:   ui.NewHomeModel(cfg, client),
+		coinModel:   ui.NewCoinModel(cfg, client),
+		watchlist:   ui.NewWatchlistModel(cfg, client),
+		markets:     ui.NewMarketsModel(cfg, client),
+		portfolio:   ui.NewPortfolioModel(cfg, client),
+		alerts:      ui.NewAlertsModel(cfg, client),
+		client:      client,
 		config:      cfg,
 	}
 }
 
 func (m mainModel) Init() tea.Cmd {
-	return m.homeModel.Init()
+	_, checkAlerts := m.alerts.Check()
+	return tea.Batch(m.homeModel.Init(), ui.FetchCurrencies(m.client), checkAlerts, m.alerts.Init())
 }
 
 func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -51,26 +75,36 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		
-		// Forward to current view
-		switch m.currentView {
-		case homeView:
-			var cmd tea.Cmd
-			model, cmd := m.homeModel.Update(msg)
-			m.homeModel = model.(ui.HomeModel)
-			return m, cmd
-		case coinView:
-			var cmd tea.Cmd
-			model, cmd := m.coinModel.Update(msg)
-			m.coinModel = model.(ui.CoinModel)
-			return m, cmd
-		}
+		// Every view needs the size, not just the one on screen
+		return m.broadcast(msg)
+
+	case ui.OpenCoinMsg:
+		m.currentView = coinView
+		var cmd tea.Cmd
+		m.coinModel, cmd = m.coinModel.Open(string(msg))
+		return m, cmd
+
+	case ui.CurrenciesMsg, ui.CurrencyChangedMsg:
+		// Currency state is shared, so every view hears about it
+		return m.broadcast(msg)
+
+	case ui.AlertChecksMsg, ui.AlertTickMsg, ui.AlertBannerExpiredMsg:
+		// Alerts are announced whichever view is showing
+		model, cmd := m.alerts.Update(msg)
+		m.alerts = model.(ui.AlertsModel)
+		return m, cmd
 
 	case tea.KeyMsg:
+		if m.editing() && msg.String() != "ctrl+c" {
+			// Forms need every key, including q, tab and esc
+			break
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		case "/", "s":
-			if m.currentView == homeView {
+			if m.currentView == homeView || m.currentView == watchlistView || m.currentView == marketsView || m.currentView == portfolioView || m.currentView == alertsView {
 				m.currentView = coinView
 				m.coinModel = m.coinModel.Reset()
 				return m, m.coinModel.Init()
@@ -83,17 +117,63 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.coinModel.Init()
 			} else {
 				m.currentView = homeView
+				m.coinModel = m.coinModel.Reset()
 				return m, m.homeModel.Init()
 			}
+		case "w":
+			if m.currentView == homeView {
+				m.currentView = watchlistView
+				var cmd tea.Cmd
+				m.watchlist, cmd = m.watchlist.Reload()
+				return m, cmd
+			}
+		case "m":
+			if m.currentView == homeView {
+				m.currentView = marketsView
+				var cmd tea.Cmd
+				m.markets, cmd = m.markets.Reload()
+				return m, cmd
+			}
+		case "r":
+			// Every refresh of market data is a chance to check the alerts
+			if m.currentView != coinView && m.currentView != alertsView {
+				var checkAlerts, cmd tea.Cmd
+				m.alerts, checkAlerts = m.alerts.Check()
+				m, cmd = m.updateCurrent(msg)
+				return m, tea.Batch(cmd, checkAlerts)
+			}
+		case "a":
+			if m.currentView == homeView {
+				m.currentView = alertsView
+				var cmd tea.Cmd
+				m.alerts, cmd = m.alerts.Check()
+				return m, cmd
+			}
+		case "p":
+			if m.currentView == homeView {
+				m.currentView = portfolioView
+				var cmd tea.Cmd
+				m.portfolio, cmd = m.portfolio.Reload()
+				return m, cmd
+			}
 		case "esc":
 			if m.currentView == coinView {
 				m.currentView = homeView
-				return m, nil
+				m.coinModel = m.coinModel.Reset()
+				return m, m.homeModel.Init()
+			}
+			if m.currentView == watchlistView || m.currentView == marketsView || m.currentView == portfolioView || m.currentView == alertsView {
+				m.currentView = homeView
+				return m, m.homeModel.Init()
 			}
 		}
 	}
 
-	// Forward to current view
+	return m.updateCurrent(msg)
+}
+
+// updateCurrent forwards msg to the view on screen.
+func (m mainModel) updateCurrent(msg tea.Msg) (mainModel, tea.Cmd) {
 	switch m.currentView {
 	case homeView:
 		var cmd tea.Cmd
@@ -105,22 +185,98 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		model, cmd := m.coinModel.Update(msg)
 		m.coinModel = model.(ui.CoinModel)
 		return m, cmd
+	case watchlistView:
+		var cmd tea.Cmd
+		model, cmd := m.watchlist.Update(msg)
+		m.watchlist = model.(ui.WatchlistModel)
+		return m, cmd
+	case marketsView:
+		var cmd tea.Cmd
+		model, cmd := m.markets.Update(msg)
+		m.markets = model.(ui.MarketsModel)
+		return m, cmd
+	case portfolioView:
+		var cmd tea.Cmd
+		model, cmd := m.portfolio.Update(msg)
+		m.portfolio = model.(ui.PortfolioModel)
+		return m, cmd
+	case alertsView:
+		var cmd tea.Cmd
+		model, cmd := m.alerts.Update(msg)
+		m.alerts = model.(ui.AlertsModel)
+		return m, cmd
 	}
 
 	return m, nil
 }
 
+// editing reports whether the view on screen is taking text input that
+// global shortcuts would otherwise steal.
+func (m mainModel) editing() bool {
+	switch m.currentView {
+	case portfolioView:
+		return m.portfolio.Editing()
+	case alertsView:
+		return m.alerts.Editing()
+	}
+	return false
+}
+
+// broadcast forwards msg to every view, not just the current one.
+func (m mainModel) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	homeModel, homeCmd := m.homeModel.Update(msg)
+	m.homeModel = homeModel.(ui.HomeModel)
+
+	coinModel, coinCmd := m.coinModel.Update(msg)
+	m.coinModel = coinModel.(ui.CoinModel)
+
+	watchlist, watchlistCmd := m.watchlist.Update(msg)
+	m.watchlist = watchlist.(ui.WatchlistModel)
+
+	markets, marketsCmd := m.markets.Update(msg)
+	m.markets = markets.(ui.MarketsModel)
+
+	portfolio, portfolioCmd := m.portfolio.Update(msg)
+	m.portfolio = portfolio.(ui.PortfolioModel)
+
+	alerts, alertsCmd := m.alerts.Update(msg)
+	m.alerts = alerts.(ui.AlertsModel)
+
+	return m, tea.Batch(homeCmd, coinCmd, watchlistCmd, marketsCmd, portfolioCmd, alertsCmd)
+}
+
 func (m mainModel) View() string {
+	view := m.currentViewString()
+	if banner := m.alerts.Banner(); banner != "" {
+		return lipgloss.JoinVertical(lipgloss.Center, banner, view)
+	}
+	return view
+}
+
+func (m mainModel) currentViewString() string {
 	switch m.currentView {
 	case homeView:
 		return m.homeModel.View()
 	case coinView:
 		return m.coinModel.View()
+	case watchlistView:
+		return m.watchlist.View()
+	case marketsView:
+		return m.markets.View()
+	case portfolioView:
+		return m.portfolio.View()
+	case alertsView:
+		return m.alerts.View()
 	}
 	return ""
 }
 
 func main() {
+	// Subcommands run headless, without Bubble Tea
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
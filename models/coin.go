@@ -3,15 +3,19 @@ package models
 import "time"
 
 type GlobalData struct {
+	Currency               string  `json:"currency"`
 	TotalMarketCap         float64 `json:"total_market_cap"`
 	TotalVolume           float64 `json:"total_volume"`
 	MarketCapChangePercentage24h float64 `json:"market_cap_change_percentage_24h"`
+	FetchedAt              time.Time `json:"fetched_at"` // When the data came from the API, not the cache
 }
 
 type Coin struct {
 	ID                        string    `json:"id"`
 	Symbol                   string    `json:"symbol"`
 	Name                     string    `json:"name"`
+	Currency                 string    `json:"currency"`
+	MarketCapRank            int       `json:"market_cap_rank"`
 	CurrentPrice             float64   `json:"current_price"`
 	MarketCap                float64   `json:"market_cap"`
 	TotalVolume              float64   `json:"total_volume"`
@@ -25,9 +29,30 @@ type Coin struct {
 	PriceChangePercentage7d   float64   `json:"price_change_percentage_7d"`
 	PriceChangePercentage30d  float64   `json:"price_change_percentage_30d"`
 	PriceChangePercentage90d  float64   `json:"price_change_percentage_90d"`
+	FetchedAt                 time.Time `json:"fetched_at"` // When the data came from the API, not the cache
 }
 
 type APIResponse struct {
 	Global *GlobalData `json:"data,omitempty"`
 	Coins  []Coin      `json:",omitempty"`
+}
+
+type PricePoint struct {
+	Time  time.Time `json:"time"`
+	Price float64   `json:"price"`
+}
+
+type PriceHistory struct {
+	CoinID   string       `json:"id"`
+	Currency string       `json:"currency"`
+	Days     string       `json:"days"`
+	Prices   []PricePoint `json:"prices"`
+}
+
+type Candle struct {
+	Time  time.Time `json:"time"`
+	Open  float64   `json:"open"`
+	High  float64   `json:"high"`
+	Low   float64   `json:"low"`
+	Close float64   `json:"close"`
 }
\ No newline at end of file
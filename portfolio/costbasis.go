@@ -0,0 +1,212 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CostBasisMethod decides which acquisition lots a sale or transfer out
+// draws from, and so the basis of each disposal.
+type CostBasisMethod string
+
+const (
+	FIFO    CostBasisMethod = "fifo"    // Oldest lots first
+	LIFO    CostBasisMethod = "lifo"    // Newest lots first
+	HIFO    CostBasisMethod = "hifo"    // Most expensive lots first
+	Average CostBasisMethod = "average" // Every coin held at the pooled average cost
+)
+
+// CostBasisMethods lists every method in display order.
+var CostBasisMethods = []CostBasisMethod{FIFO, LIFO, HIFO, Average}
+
+func (m CostBasisMethod) String() string {
+	switch m {
+	case FIFO, LIFO, HIFO:
+		return strings.ToUpper(string(m))
+	case Average:
+		return "Average"
+	}
+	return string(m)
+}
+
+// Disposal is the sale of part or all of one acquisition lot. A sale that
+// draws from several lots produces one disposal per lot.
+type Disposal struct {
+	CoinID   string    `json:"coin_id"`
+	Quantity float64   `json:"quantity"`
+	Acquired time.Time `json:"date_acquired"`
+	Sold     time.Time `json:"date_sold"`
+	Proceeds float64   `json:"proceeds"` // Net of the sale's fee, shared across its lots
+	Basis    float64   `json:"basis"`
+	Gain     float64   `json:"gain"`
+	LongTerm bool      `json:"long_term"` // Held for more than a year
+}
+
+// lot is a quantity of a coin acquired at one time and unit cost.
+type lot struct {
+	acquired time.Time
+	quantity float64
+	unitCost float64 // Fees included
+}
+
+// replay walks transactions in date order, matching sales and transfers out
+// against acquisition lots with method. It returns the holding per coin,
+// sorted by coin ID, and every disposal in the order it happened.
+func replay(transactions []Transaction, method CostBasisMethod) ([]Holding, []Disposal, error) {
+	ordered := append([]Transaction(nil), transactions...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Date.Before(ordered[j].Date)
+	})
+
+	lots := make(map[string][]lot)
+	realized := make(map[string]float64)
+	var disposals []Disposal
+
+	for _, tx := range ordered {
+		if _, ok := realized[tx.CoinID]; !ok {
+			realized[tx.CoinID] = 0
+		}
+
+		switch tx.Type {
+		case Buy, TransferIn:
+			lots[tx.CoinID] = append(lots[tx.CoinID], lot{
+				acquired: tx.Date,
+				quantity: tx.Quantity,
+				unitCost: (tx.Quantity*tx.Price + tx.Fee) / tx.Quantity,
+			})
+			if method == Average {
+				pool(lots[tx.CoinID])
+			}
+
+		case Sell, TransferOut:
+			held := 0.0
+			for _, l := range lots[tx.CoinID] {
+				held += l.quantity
+			}
+			if tx.Quantity > held+epsilon {
+				return nil, nil, fmt.Errorf("%s on %s exceeds the %g %s held",
+					tx.Type, tx.Date.Format("2006-01-02"), held, tx.CoinID)
+			}
+
+			remaining, matched := match(lots[tx.CoinID], tx.Quantity, method)
+			lots[tx.CoinID] = remaining
+
+			if tx.Type == TransferOut {
+				// Moving coins isn't a disposal, but the fee is still a cost
+				realized[tx.CoinID] -= tx.Fee
+				continue
+			}
+
+			for _, l := range matched {
+				share := l.quantity / tx.Quantity
+				proceeds := l.quantity*tx.Price - tx.Fee*share
+				basis := l.quantity * l.unitCost
+				disposals = append(disposals, Disposal{
+					CoinID:   tx.CoinID,
+					Quantity: l.quantity,
+					Acquired: l.acquired,
+					Sold:     tx.Date,
+					Proceeds: proceeds,
+					Basis:    basis,
+					Gain:     proceeds - basis,
+					LongTerm: tx.Date.After(l.acquired.AddDate(1, 0, 0)),
+				})
+				realized[tx.CoinID] += proceeds - basis
+			}
+		}
+	}
+
+	holdings := make([]Holding, 0, len(realized))
+	for coinID, pnl := range realized {
+		holding := Holding{CoinID: coinID, RealizedPnL: pnl}
+		for _, l := range lots[coinID] {
+			holding.Quantity += l.quantity
+			holding.CostBasis += l.quantity * l.unitCost
+		}
+		if holding.Quantity < epsilon {
+			holding.Quantity = 0
+			holding.CostBasis = 0
+		}
+		holdings = append(holdings, holding)
+	}
+	sort.Slice(holdings, func(i, j int) bool {
+		return holdings[i].CoinID < holdings[j].CoinID
+	})
+
+	return holdings, disposals, nil
+}
+
+// match takes quantity out of lots in the order method prescribes, returning
+// the lots left over and the portions taken.
+func match(lots []lot, quantity float64, method CostBasisMethod) ([]lot, []lot) {
+	remaining := append([]lot(nil), lots...)
+	switch method {
+	case LIFO:
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return remaining[i].acquired.After(remaining[j].acquired)
+		})
+	case HIFO:
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return remaining[i].unitCost > remaining[j].unitCost
+		})
+	default:
+		// FIFO, and average cost where every lot has the same unit cost
+		// but the holding period still runs from the oldest coins
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return remaining[i].acquired.Before(remaining[j].acquired)
+		})
+	}
+
+	var matched []lot
+	for i := range remaining {
+		if quantity < epsilon {
+			break
+		}
+
+		taken := min(quantity, remaining[i].quantity)
+		portion := remaining[i]
+		portion.quantity = taken
+		matched = append(matched, portion)
+
+		remaining[i].quantity -= taken
+		quantity -= taken
+	}
+
+	left := remaining[:0]
+	for _, l := range remaining {
+		if l.quantity >= epsilon {
+			left = append(left, l)
+		}
+	}
+
+	// Keep lots in acquisition order for the next match
+	sort.SliceStable(left, func(i, j int) bool {
+		return left[i].acquired.Before(left[j].acquired)
+	})
+	return left, matched
+}
+
+// pool reprices every lot at the average unit cost of the whole holding.
+func pool(lots []lot) {
+	var quantity, cost float64
+	for _, l := range lots {
+		quantity += l.quantity
+		cost += l.quantity * l.unitCost
+	}
+	if quantity <= 0 {
+		return
+	}
+
+	for i := range lots {
+		lots[i].unitCost = cost / quantity
+	}
+}
+
+// Disposals lists every sale in transactions, split by the lots it drew
+// from under method.
+func Disposals(transactions []Transaction, method CostBasisMethod) ([]Disposal, error) {
+	_, disposals, err := replay(transactions, method)
+	return disposals, err
+}
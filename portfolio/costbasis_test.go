@@ -0,0 +1,153 @@
+package portfolio
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// lotsThenSale buys three lots at different prices and sells half of them,
+// so every method consumes one lot whole and another in part.
+var lotsThenSale = []Transaction{
+	{CoinID: "bitcoin", Type: Sell, Quantity: 1.5, Price: 400, Date: date(2024, time.March, 1)},
+	{CoinID: "bitcoin", Type: Buy, Quantity: 1, Price: 100, Date: date(2023, time.January, 1)},
+	{CoinID: "bitcoin", Type: Buy, Quantity: 1, Price: 300, Date: date(2023, time.June, 1)},
+	{CoinID: "bitcoin", Type: Buy, Quantity: 1, Price: 200, Date: date(2024, time.January, 1)},
+}
+
+func TestCostBasisMethods(t *testing.T) {
+	tests := []struct {
+		method        CostBasisMethod
+		wantDisposals []Disposal
+		wantHolding   Holding
+	}{
+		{
+			method: FIFO,
+			wantDisposals: []Disposal{
+				{Quantity: 1, Acquired: date(2023, time.January, 1), Proceeds: 400, Basis: 100, Gain: 300, LongTerm: true},
+				{Quantity: 0.5, Acquired: date(2023, time.June, 1), Proceeds: 200, Basis: 150, Gain: 50},
+			},
+			wantHolding: Holding{CoinID: "bitcoin", Quantity: 1.5, CostBasis: 350, RealizedPnL: 350},
+		},
+		{
+			method: LIFO,
+			wantDisposals: []Disposal{
+				{Quantity: 1, Acquired: date(2024, time.January, 1), Proceeds: 400, Basis: 200, Gain: 200},
+				{Quantity: 0.5, Acquired: date(2023, time.June, 1), Proceeds: 200, Basis: 150, Gain: 50},
+			},
+			wantHolding: Holding{CoinID: "bitcoin", Quantity: 1.5, CostBasis: 250, RealizedPnL: 250},
+		},
+		{
+			method: HIFO,
+			wantDisposals: []Disposal{
+				{Quantity: 1, Acquired: date(2023, time.June, 1), Proceeds: 400, Basis: 300, Gain: 100},
+				{Quantity: 0.5, Acquired: date(2024, time.January, 1), Proceeds: 200, Basis: 100, Gain: 100},
+			},
+			wantHolding: Holding{CoinID: "bitcoin", Quantity: 1.5, CostBasis: 200, RealizedPnL: 200},
+		},
+		{
+			method: Average,
+			wantDisposals: []Disposal{
+				{Quantity: 1, Acquired: date(2023, time.January, 1), Proceeds: 400, Basis: 200, Gain: 200, LongTerm: true},
+				{Quantity: 0.5, Acquired: date(2023, time.June, 1), Proceeds: 200, Basis: 100, Gain: 100},
+			},
+			wantHolding: Holding{CoinID: "bitcoin", Quantity: 1.5, CostBasis: 300, RealizedPnL: 300},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method.String(), func(t *testing.T) {
+			holdings, disposals, err := replay(lotsThenSale, tt.method)
+			if err != nil {
+				t.Fatalf("replay() error = %v", err)
+			}
+
+			if len(disposals) != len(tt.wantDisposals) {
+				t.Fatalf("replay() made %d disposals, want %d: %+v", len(disposals), len(tt.wantDisposals), disposals)
+			}
+			for i, want := range tt.wantDisposals {
+				want.CoinID = "bitcoin"
+				want.Sold = date(2024, time.March, 1)
+				if got := disposals[i]; !sameDisposal(got, want) {
+					t.Errorf("disposal %d = %+v, want %+v", i, got, want)
+				}
+			}
+
+			if len(holdings) != 1 || !sameHolding(holdings[0], tt.wantHolding) {
+				t.Errorf("holdings = %+v, want [%+v]", holdings, tt.wantHolding)
+			}
+		})
+	}
+}
+
+func TestCostBasisSharesSaleFeeAcrossLots(t *testing.T) {
+	transactions := []Transaction{
+		{CoinID: "bitcoin", Type: Buy, Quantity: 1, Price: 100, Fee: 10, Date: date(2024, time.January, 1)},
+		{CoinID: "bitcoin", Type: Buy, Quantity: 2, Price: 100, Date: date(2024, time.February, 1)},
+		{CoinID: "bitcoin", Type: Sell, Quantity: 3, Price: 200, Fee: 30, Date: date(2024, time.March, 1)},
+	}
+
+	holdings, disposals, err := replay(transactions, FIFO)
+	if err != nil {
+		t.Fatalf("replay() error = %v", err)
+	}
+
+	want := []Disposal{
+		{CoinID: "bitcoin", Quantity: 1, Acquired: date(2024, time.January, 1), Sold: date(2024, time.March, 1), Proceeds: 190, Basis: 110, Gain: 80},
+		{CoinID: "bitcoin", Quantity: 2, Acquired: date(2024, time.February, 1), Sold: date(2024, time.March, 1), Proceeds: 380, Basis: 200, Gain: 180},
+	}
+	for i := range want {
+		if i >= len(disposals) || !sameDisposal(disposals[i], want[i]) {
+			t.Fatalf("disposals = %+v, want %+v", disposals, want)
+		}
+	}
+
+	wantHolding := Holding{CoinID: "bitcoin", RealizedPnL: 260}
+	if len(holdings) != 1 || !sameHolding(holdings[0], wantHolding) {
+		t.Errorf("holdings = %+v, want [%+v]", holdings, wantHolding)
+	}
+}
+
+func TestCostBasisRejectsSellingMoreThanHeld(t *testing.T) {
+	transactions := []Transaction{
+		{CoinID: "bitcoin", Type: Buy, Quantity: 1, Price: 100, Date: date(2024, time.January, 1)},
+		{CoinID: "bitcoin", Type: Sell, Quantity: 0.5, Price: 200, Date: date(2024, time.February, 1)},
+		{CoinID: "bitcoin", Type: Sell, Quantity: 0.75, Price: 200, Date: date(2024, time.March, 1)},
+	}
+
+	for _, method := range CostBasisMethods {
+		t.Run(method.String(), func(t *testing.T) {
+			_, _, err := replay(transactions, method)
+			if err == nil || !strings.Contains(err.Error(), "exceeds the 0.5 bitcoin held") {
+				t.Errorf("replay() error = %v, want one about exceeding the 0.5 bitcoin held", err)
+			}
+		})
+	}
+}
+
+func sameDisposal(got, want Disposal) bool {
+	return got.CoinID == want.CoinID &&
+		near(got.Quantity, want.Quantity) &&
+		got.Acquired.Equal(want.Acquired) &&
+		got.Sold.Equal(want.Sold) &&
+		near(got.Proceeds, want.Proceeds) &&
+		near(got.Basis, want.Basis) &&
+		near(got.Gain, want.Gain) &&
+		got.LongTerm == want.LongTerm
+}
+
+func sameHolding(got, want Holding) bool {
+	return got.CoinID == want.CoinID &&
+		near(got.Quantity, want.Quantity) &&
+		near(got.CostBasis, want.CostBasis) &&
+		near(got.RealizedPnL, want.RealizedPnL)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < epsilon
+}
@@ -0,0 +1,94 @@
+package portfolio
+
+import (
+	"context"
+
+	"neongecko/api"
+	"neongecko/models"
+)
+
+// epsilon absorbs floating point noise when a position is fully closed.
+const epsilon = 1e-9
+
+// Holding is the current position in one coin.
+type Holding struct {
+	CoinID      string  `json:"coin_id"`
+	Quantity    float64 `json:"quantity"`
+	CostBasis   float64 `json:"cost_basis"`   // Total cost of the quantity held, fees included
+	RealizedPnL float64 `json:"realized_pnl"` // Gains locked in by sales, net of fees
+}
+
+// AverageCost is the cost per coin currently held.
+func (h Holding) AverageCost() float64 {
+	if h.Quantity <= 0 {
+		return 0
+	}
+	return h.CostBasis / h.Quantity
+}
+
+// ComputeHoldings replays transactions in date order and returns the
+// resulting holding per coin, sorted by coin ID. Sales and transfers out
+// take their basis from the lots method picks; sales realize the difference
+// to their proceeds.
+func ComputeHoldings(transactions []Transaction, method CostBasisMethod) ([]Holding, error) {
+	holdings, _, err := replay(transactions, method)
+	return holdings, err
+}
+
+// Position is a holding valued at the current market price. Without a price
+// Priced is false and Value and UnrealizedPnL are zero.
+type Position struct {
+	Holding
+	Coin          models.Coin `json:"coin"`
+	Priced        bool        `json:"priced"`
+	Value         float64     `json:"value"`
+	UnrealizedPnL float64     `json:"unrealized_pnl"`
+}
+
+// Valuate prices holdings at current market prices from provider, fetching
+// every coin in a single request. Coins the provider doesn't know are left
+// unpriced rather than valued at zero.
+func Valuate(ctx context.Context, provider api.Provider, currency string, holdings []Holding) ([]Position, error) {
+	var ids []string
+	for _, holding := range holdings {
+		ids = append(ids, holding.CoinID)
+	}
+
+	prices := make(map[string]models.Coin)
+	if len(ids) > 0 {
+		coins, err := provider.GetMarketsContext(ctx, api.MarketsQuery{
+			Currency: currency,
+			IDs:      ids,
+			PerPage:  len(ids),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, coin := range coins {
+			prices[coin.ID] = coin
+		}
+	}
+
+	positions := make([]Position, 0, len(holdings))
+	for _, holding := range holdings {
+		coin, ok := prices[holding.CoinID]
+		if !ok {
+			positions = append(positions, Position{
+				Holding: holding,
+				Coin:    models.Coin{ID: holding.CoinID, Symbol: holding.CoinID, Name: holding.CoinID, Currency: currency},
+			})
+			continue
+		}
+
+		value := holding.Quantity * coin.CurrentPrice
+		positions = append(positions, Position{
+			Holding:       holding,
+			Coin:          coin,
+			Priced:        true,
+			Value:         value,
+			UnrealizedPnL: value - holding.CostBasis,
+		})
+	}
+
+	return positions, nil
+}
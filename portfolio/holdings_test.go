@@ -0,0 +1,39 @@
+package portfolio
+
+import (
+	"context"
+	"testing"
+
+	"neongecko/api"
+	"neongecko/models"
+)
+
+// marketsProvider returns coins from GetMarketsContext; other methods panic.
+type marketsProvider struct {
+	api.Provider
+	coins []models.Coin
+}
+
+func (p marketsProvider) GetMarketsContext(ctx context.Context, query api.MarketsQuery) ([]models.Coin, error) {
+	return p.coins, nil
+}
+
+func TestValuateLeavesUnknownCoinsUnpriced(t *testing.T) {
+	provider := marketsProvider{coins: []models.Coin{{ID: "bitcoin", CurrentPrice: 60000}}}
+	holdings := []Holding{
+		{CoinID: "bitcoin", Quantity: 2, CostBasis: 100000},
+		{CoinID: "delisted", Quantity: 5, CostBasis: 500},
+	}
+
+	positions, err := Valuate(context.Background(), provider, "usd", holdings)
+	if err != nil {
+		t.Fatalf("Valuate() error = %v", err)
+	}
+
+	if got := positions[0]; !got.Priced || got.Value != 120000 || got.UnrealizedPnL != 20000 {
+		t.Errorf("bitcoin = priced %v, value %v, unrealized %v, want priced, 120000, 20000", got.Priced, got.Value, got.UnrealizedPnL)
+	}
+	if got := positions[1]; got.Priced || got.Value != 0 || got.UnrealizedPnL != 0 {
+		t.Errorf("delisted = priced %v, value %v, unrealized %v, want unpriced with no value or P&L", got.Priced, got.Value, got.UnrealizedPnL)
+	}
+}
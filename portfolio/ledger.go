@@ -0,0 +1,238 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"neongecko/config"
+)
+
+type TransactionType string
+
+const (
+	Buy         TransactionType = "buy"
+	Sell        TransactionType = "sell"
+	TransferIn  TransactionType = "transfer_in"  // Coins received from elsewhere, e.g. another wallet
+	TransferOut TransactionType = "transfer_out" // Coins sent elsewhere without being sold
+)
+
+// TransactionTypes lists every transaction type in display order.
+var TransactionTypes = []TransactionType{Buy, Sell, TransferIn, TransferOut}
+
+type Transaction struct {
+	ID       string          `json:"id"`
+	CoinID   string          `json:"coin_id"`
+	Type     TransactionType `json:"type"`
+	Quantity float64         `json:"quantity"`
+	Price    float64         `json:"price"` // Per coin, in the ledger currency
+	Fee      float64         `json:"fee"`   // In the ledger currency
+	Date     time.Time       `json:"date"`
+	Note     string          `json:"note,omitempty"`
+}
+
+// Ledger is the full transaction history of a portfolio. All prices and
+// fees are in Currency.
+type Ledger struct {
+	Currency     string          `json:"currency"`
+	CostBasis    CostBasisMethod `json:"cost_basis,omitempty"`
+	Transactions []Transaction   `json:"transactions"`
+	NextID       int             `json:"next_id"`
+}
+
+func GetLedgerPath() (string, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), "portfolio.json"), nil
+}
+
+// LoadLedger reads the ledger from disk, returning an empty ledger in
+// currency if none has been saved yet.
+func LoadLedger(currency string) (*Ledger, error) {
+	ledgerPath, err := GetLedgerPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(ledgerPath)
+	if os.IsNotExist(err) {
+		return &Ledger{Currency: currency, CostBasis: FIFO, NextID: 1}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read portfolio file: %w", err)
+	}
+
+	var ledger Ledger
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse portfolio file: %w", err)
+	}
+	if ledger.Currency == "" {
+		ledger.Currency = currency
+	}
+
+	return &ledger, nil
+}
+
+func SaveLedger(ledger *Ledger) error {
+	ledgerPath, err := GetLedgerPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal portfolio: %w", err)
+	}
+
+	if err := os.WriteFile(ledgerPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write portfolio file: %w", err)
+	}
+
+	return nil
+}
+
+// Method is the ledger's cost-basis method, FIFO unless set.
+func (l *Ledger) Method() CostBasisMethod {
+	for _, method := range CostBasisMethods {
+		if l.CostBasis == method {
+			return method
+		}
+	}
+	return FIFO
+}
+
+// Holdings computes the current holdings with the ledger's method.
+func (l *Ledger) Holdings() ([]Holding, error) {
+	return ComputeHoldings(l.Transactions, l.Method())
+}
+
+// Disposals lists every sale with the ledger's method.
+func (l *Ledger) Disposals() ([]Disposal, error) {
+	return Disposals(l.Transactions, l.Method())
+}
+
+// Add records tx with a new ID and returns it.
+func (l *Ledger) Add(tx Transaction) (Transaction, error) {
+	if l.NextID < 1 {
+		l.NextID = 1
+	}
+	tx.ID = strconv.Itoa(l.NextID)
+
+	if err := l.check(append(l.Transactions, tx)); err != nil {
+		return Transaction{}, err
+	}
+
+	l.NextID++
+	l.Transactions = append(l.Transactions, tx)
+	l.sort()
+	return tx, nil
+}
+
+// Update replaces the transaction with the same ID as tx.
+func (l *Ledger) Update(tx Transaction) error {
+	index := l.index(tx.ID)
+	if index < 0 {
+		return fmt.Errorf("transaction %s not found", tx.ID)
+	}
+
+	updated := append([]Transaction(nil), l.Transactions...)
+	updated[index] = tx
+	if err := l.check(updated); err != nil {
+		return err
+	}
+
+	l.Transactions = updated
+	l.sort()
+	return nil
+}
+
+// Delete removes the transaction with the given ID.
+func (l *Ledger) Delete(id string) error {
+	index := l.index(id)
+	if index < 0 {
+		return fmt.Errorf("transaction %s not found", id)
+	}
+
+	remaining := append(append([]Transaction(nil), l.Transactions[:index]...), l.Transactions[index+1:]...)
+	if err := l.check(remaining); err != nil {
+		return err
+	}
+
+	l.Transactions = remaining
+	return nil
+}
+
+// Find returns the transaction with the given ID.
+func (l *Ledger) Find(id string) (Transaction, bool) {
+	if index := l.index(id); index >= 0 {
+		return l.Transactions[index], true
+	}
+	return Transaction{}, false
+}
+
+func (l *Ledger) index(id string) int {
+	for i, tx := range l.Transactions {
+		if tx.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// sort keeps transactions in the order they happened.
+func (l *Ledger) sort() {
+	sort.SliceStable(l.Transactions, func(i, j int) bool {
+		return l.Transactions[i].Date.Before(l.Transactions[j].Date)
+	})
+}
+
+// check validates each transaction and that no coin is ever sold or sent
+// beyond what was held at the time.
+func (l *Ledger) check(transactions []Transaction) error {
+	for _, tx := range transactions {
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+	}
+
+	_, err := ComputeHoldings(transactions, l.Method())
+	return err
+}
+
+// Validate checks the fields of a single transaction.
+func (tx Transaction) Validate() error {
+	switch {
+	case tx.CoinID == "":
+		return fmt.Errorf("coin is required")
+	case !finite(tx.Quantity) || !finite(tx.Price) || !finite(tx.Fee):
+		// NaN slips past the comparisons below and can't be saved as JSON
+		return fmt.Errorf("quantity, price and fee must be numbers")
+	case tx.Quantity <= 0:
+		return fmt.Errorf("quantity must be positive")
+	case tx.Price < 0:
+		return fmt.Errorf("price cannot be negative")
+	case tx.Fee < 0:
+		return fmt.Errorf("fee cannot be negative")
+	case tx.Date.IsZero():
+		return fmt.Errorf("date is required")
+	}
+
+	for _, t := range TransactionTypes {
+		if tx.Type == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown transaction type %q", tx.Type)
+}
+
+func finite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
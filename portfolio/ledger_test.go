@@ -0,0 +1,42 @@
+package portfolio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTransactionValidateRejectsNonFinite(t *testing.T) {
+	valid := Transaction{
+		CoinID:   "bitcoin",
+		Type:     Buy,
+		Quantity: 1,
+		Price:    50000,
+		Fee:      10,
+		Date:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() of a valid transaction = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(tx *Transaction)
+	}{
+		{"NaN quantity", func(tx *Transaction) { tx.Quantity = math.NaN() }},
+		{"infinite quantity", func(tx *Transaction) { tx.Quantity = math.Inf(1) }},
+		{"NaN price", func(tx *Transaction) { tx.Price = math.NaN() }},
+		{"infinite price", func(tx *Transaction) { tx.Price = math.Inf(1) }},
+		{"NaN fee", func(tx *Transaction) { tx.Fee = math.NaN() }},
+		{"infinite fee", func(tx *Transaction) { tx.Fee = math.Inf(1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := valid
+			tt.modify(&tx)
+			if err := tx.Validate(); err == nil {
+				t.Errorf("Validate() = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// YearReport totals the disposals of one calendar year.
+type YearReport struct {
+	Year          int        `json:"year"`
+	Disposals     []Disposal `json:"disposals"`
+	Proceeds      float64    `json:"proceeds"`
+	Basis         float64    `json:"basis"`
+	ShortTermGain float64    `json:"short_term_gain"`
+	LongTermGain  float64    `json:"long_term_gain"`
+}
+
+// Gain is the year's total gain, short and long term combined.
+func (r YearReport) Gain() float64 {
+	return r.ShortTermGain + r.LongTermGain
+}
+
+// Report groups disposals by the year they were sold, oldest year first.
+func Report(disposals []Disposal) []YearReport {
+	byYear := make(map[int]*YearReport)
+	for _, disposal := range disposals {
+		year := disposal.Sold.Year()
+		report, ok := byYear[year]
+		if !ok {
+			report = &YearReport{Year: year}
+			byYear[year] = report
+		}
+
+		report.Disposals = append(report.Disposals, disposal)
+		report.Proceeds += disposal.Proceeds
+		report.Basis += disposal.Basis
+		if disposal.LongTerm {
+			report.LongTermGain += disposal.Gain
+		} else {
+			report.ShortTermGain += disposal.Gain
+		}
+	}
+
+	reports := make([]YearReport, 0, len(byYear))
+	for _, report := range byYear {
+		reports = append(reports, *report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Year < reports[j].Year
+	})
+
+	return reports
+}
+
+// WriteCSV writes disposals as CSV with a header row. Amounts are in the
+// ledger currency, rounded to eight decimals so crypto-priced ledgers keep
+// their precision.
+func WriteCSV(w io.Writer, disposals []Disposal) error {
+	writer := csv.NewWriter(w)
+	header := []string{"coin", "quantity", "date_acquired", "date_sold", "proceeds", "basis", "gain", "term"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, disposal := range disposals {
+		term := "short"
+		if disposal.LongTerm {
+			term = "long"
+		}
+
+		record := []string{
+			disposal.CoinID,
+			formatAmount(disposal.Quantity),
+			disposal.Acquired.Format("2006-01-02"),
+			disposal.Sold.Format("2006-01-02"),
+			formatAmount(disposal.Proceeds),
+			formatAmount(disposal.Basis),
+			formatAmount(disposal.Gain),
+			term,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1e8)/1e8, 'f', -1, 64)
+}
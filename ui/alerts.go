@@ -0,0 +1,412 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/alerts"
+	"neongecko/api"
+	"neongecko/config"
+)
+
+// alertBannerDuration is how long fired alerts stay on screen.
+const alertBannerDuration = 15 * time.Second
+
+// minAlertInterval bounds how often rules are checked when the cache TTL is
+// very short or zero.
+const minAlertInterval = time.Minute
+
+// AlertsModel manages alert rules and checks them against market data.
+// Checks run from every view, so fired alerts are announced wherever the
+// user is.
+type AlertsModel struct {
+	client    api.Provider
+	currency  string
+	interval  time.Duration // Between checks; cached data refreshes this often
+	rules     *alerts.RuleSet
+	rulesErr  error                   // Loading or saving the rules failed
+	checks    map[string]alerts.Check // Latest check per rule ID
+	checking  bool
+	checkErr  error
+	checkedAt time.Time
+	banner    []string // Messages of the alerts that last fired
+	bannerID  int      // Bumped per banner so only the latest expires it
+	input     textinput.Model
+	adding    bool
+	resolving bool
+	addErr    error
+	selected  int
+	width     int
+	height    int
+}
+
+func NewAlertsModel(cfg *config.Config, provider api.Provider) AlertsModel {
+	ti := textinput.New()
+	ti.Placeholder = "bitcoin above 100k, eth 24h change below -5%..."
+	ti.CharLimit = 100
+	ti.Width = 50
+
+	rules, err := alerts.LoadRules()
+	return AlertsModel{
+		client:   provider,
+		currency: cfg.GetCurrency(),
+		interval: max(cfg.GetCacheTTL(), minAlertInterval),
+		rules:    rules,
+		rulesErr: err,
+		checks:   make(map[string]alerts.Check),
+		input:    ti,
+	}
+}
+
+// Init starts checking the rules every time the cached market data they
+// read is due for a refresh.
+func (m AlertsModel) Init() tea.Cmd {
+	return alertTick(m.interval)
+}
+
+// Check evaluates every rule, unless a check is already running.
+func (m AlertsModel) Check() (AlertsModel, tea.Cmd) {
+	if m.checking {
+		return m, nil
+	}
+	m = m.reloadRules()
+	if m.rules == nil || len(m.rules.Rules) == 0 {
+		return m, nil
+	}
+	m.checking = true
+
+	rules := append([]alerts.Rule(nil), m.rules.Rules...)
+	return m, func() tea.Msg {
+		now := time.Now()
+		checks, err := alerts.Evaluate(context.Background(), m.client, rules, now)
+		return AlertChecksMsg{checks: checks, err: err, at: now}
+	}
+}
+
+// Editing reports whether the view is capturing keys for a new rule.
+func (m AlertsModel) Editing() bool {
+	return m.adding
+}
+
+// Banner renders the alerts that last fired, or "" if there are none on
+// screen.
+func (m AlertsModel) Banner() string {
+	if len(m.banner) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(m.banner))
+	for i, message := range m.banner {
+		lines[i] = WarningStyle.Render("🔔 " + message)
+	}
+	return BoxStyle.BorderForeground(peach).Render(strings.Join(lines, "\n"))
+}
+
+func (m AlertsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.adding {
+			return m.updateInput(msg)
+		}
+
+		switch msg.String() {
+		case "a":
+			m.adding = true
+			m.addErr = nil
+			m.input.SetValue("")
+			m.input.Focus()
+			return m, textinput.Blink
+		case "d", "delete":
+			if m.rules != nil && len(m.rules.Rules) > 0 {
+				return m.deleteSelected()
+			}
+		case "r":
+			return m.Check()
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case "down", "j":
+			if m.rules != nil && m.selected < len(m.rules.Rules)-1 {
+				m.selected++
+			}
+			return m, nil
+		}
+
+	case ruleResolvedMsg:
+		if !m.resolving {
+			// Adding was cancelled while the coin was looked up
+			return m, nil
+		}
+		m.resolving = false
+		if msg.err != nil {
+			m.addErr = msg.err
+			return m, nil
+		}
+
+		if m = m.reloadRules(); m.rules == nil {
+			return m, nil
+		}
+		m.rules.Add(msg.rule)
+		m.rulesErr = alerts.SaveRules(m.rules)
+		m.adding = false
+		m.input.Blur()
+		m.selected = len(m.rules.Rules) - 1
+		return m.Check()
+
+	case AlertChecksMsg:
+		m.checking = false
+		m.checkErr = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		if m = m.reloadRules(); m.rules == nil {
+			return m, nil
+		}
+
+		m.checkedAt = msg.at
+		for _, check := range msg.checks {
+			m.checks[check.Rule.ID] = check
+		}
+
+		fired := m.rules.Apply(msg.checks, msg.at)
+		m.rulesErr = alerts.SaveRules(m.rules)
+		if len(fired) == 0 {
+			return m, nil
+		}
+
+		m.banner = nil
+		for _, check := range fired {
+			m.banner = append(m.banner, check.Message)
+		}
+		m.bannerID++
+		return m, tea.Batch(ringBell, expireBanner(m.bannerID))
+
+	case AlertTickMsg:
+		var cmd tea.Cmd
+		m, cmd = m.Check()
+		return m, tea.Batch(cmd, alertTick(m.interval))
+
+	case AlertBannerExpiredMsg:
+		if msg.id == m.bannerID {
+			m.banner = nil
+		}
+		return m, nil
+
+	case CurrencyChangedMsg:
+		// New rules default to the currency on screen
+		m.currency = string(msg)
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m AlertsModel) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.adding = false
+		m.resolving = false
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		if m.resolving {
+			return m, nil
+		}
+
+		rule, err := alerts.Parse(m.input.Value(), m.currency)
+		if err != nil {
+			m.addErr = err
+			return m, nil
+		}
+
+		m.resolving = true
+		m.addErr = nil
+		return m, func() tea.Msg {
+			rule, err := alerts.Resolve(context.Background(), m.client, rule)
+			return ruleResolvedMsg{rule: rule, err: err}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m AlertsModel) deleteSelected() (tea.Model, tea.Cmd) {
+	rule := m.rules.Rules[m.selected]
+	if m = m.reloadRules(); m.rules == nil {
+		return m, nil
+	}
+
+	// A rule already deleted elsewhere needs no saving
+	if err := m.rules.Delete(rule.ID); err == nil {
+		m.rulesErr = alerts.SaveRules(m.rules)
+	}
+	delete(m.checks, rule.ID)
+	m.selected = min(m.selected, max(len(m.rules.Rules)-1, 0))
+	return m, nil
+}
+
+// reloadRules rereads the rules file, so changes are made on top of what
+// the daemon or another instance saved rather than overwriting it. The
+// rules are nil if they can't be read.
+func (m AlertsModel) reloadRules() AlertsModel {
+	m.rules, m.rulesErr = alerts.LoadRules()
+	if m.rules != nil {
+		m.selected = min(m.selected, max(len(m.rules.Rules)-1, 0))
+	}
+	return m
+}
+
+func (m AlertsModel) View() string {
+	if m.rules == nil {
+		return BaseStyle.Render(renderError(m.rulesErr))
+	}
+
+	title := TitleStyle.Render("🔔 Price Alerts")
+
+	var input string
+	if m.adding {
+		prompt := m.input.View()
+		if m.resolving {
+			prompt = LabelStyle.Render("Looking up coin...")
+		}
+		input = SearchStyle.Render(prompt)
+		if m.addErr != nil {
+			input = lipgloss.JoinVertical(lipgloss.Center, input, ErrorStyle.Render("❌ "+m.addErr.Error()))
+		}
+	}
+
+	var body string
+	if len(m.rules.Rules) == 0 {
+		body = BoxStyle.Render(HelpStyle.Render("No alerts yet. Press a and type a rule like \"bitcoin above 100k\"."))
+	} else {
+		body = BoxStyle.Render(strings.Join(renderTable(m.ruleColumns(), m.rules.Rules, m.selected), "\n"))
+	}
+
+	// The table only flags a rule that couldn't be checked; say why for
+	// the selected one
+	var selectedErr error
+	if len(m.rules.Rules) > 0 {
+		selectedErr = m.checks[m.rules.Rules[m.selected].ID].Err
+	}
+
+	var status string
+	switch {
+	case m.rulesErr != nil:
+		status = ErrorStyle.Render("❌ " + m.rulesErr.Error())
+	case m.checkErr != nil:
+		status = renderError(m.checkErr)
+	case selectedErr != nil:
+		status = renderError(selectedErr)
+	case m.checking:
+		status = LabelStyle.Render(loadingText(m.client, "Checking alerts..."))
+	case !m.checkedAt.IsZero():
+		status = HelpStyle.Render("Last checked " + m.checkedAt.Format("15:04:05"))
+	}
+
+	help := "a: add • d: delete • r: check now • ESC: home"
+	if m.adding {
+		help = "Enter: add rule • ESC: cancel"
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		input,
+		body,
+		status,
+		HelpStyle.Render(help),
+	)
+
+	return BaseStyle.
+		Align(lipgloss.Center).
+		Render(content)
+}
+
+// ruleColumns shows each rule with what its latest check saw.
+func (m AlertsModel) ruleColumns() []tableColumn[alerts.Rule] {
+	return []tableColumn[alerts.Rule]{
+		{title: "Rule", width: 44, left: true, cell: func(rule alerts.Rule) (string, lipgloss.Style) {
+			return rule.String(), ValueStyle
+		}},
+		{title: "Now", width: 14, cell: func(rule alerts.Rule) (string, lipgloss.Style) {
+			check, ok := m.checks[rule.ID]
+			if !ok {
+				return "-", HelpStyle
+			}
+			if check.Err != nil {
+				return "error", ErrorStyle
+			}
+			switch rule.Condition {
+			case alerts.ChangeAbove, alerts.ChangeBelow:
+				return FormatChange(check.Coin.PriceChangePercentage24h)
+			}
+			return FormatCurrency(check.Coin.CurrentPrice, rule.Currency), ValueStyle
+		}},
+		{title: "Last Fired", width: 16, cell: func(rule alerts.Rule) (string, lipgloss.Style) {
+			switch {
+			case rule.LastFired.IsZero():
+				return "never", HelpStyle
+			case !rule.Ready(time.Now()):
+				return "cooling down", WarningStyle
+			}
+			return rule.LastFired.Format("Jan 02 15:04"), ValueStyle
+		}},
+	}
+}
+
+// ringBell sounds the terminal bell. It goes to stderr so it can't land in
+// the middle of a frame being drawn on stdout.
+func ringBell() tea.Msg {
+	fmt.Fprint(os.Stderr, "\a")
+	return nil
+}
+
+func alertTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return AlertTickMsg{}
+	})
+}
+
+func expireBanner(id int) tea.Cmd {
+	return tea.Tick(alertBannerDuration, func(time.Time) tea.Msg {
+		return AlertBannerExpiredMsg{id: id}
+	})
+}
+
+// Messages
+
+// AlertChecksMsg carries the outcome of checking every alert rule. The
+// program routes it to the alerts model whichever view is showing.
+type AlertChecksMsg struct {
+	checks []alerts.Check
+	err    error
+	at     time.Time
+}
+
+// AlertTickMsg schedules the next periodic check of the alert rules.
+type AlertTickMsg struct{}
+
+// AlertBannerExpiredMsg clears the fired alerts banner.
+type AlertBannerExpiredMsg struct {
+	id int
+}
+
+type ruleResolvedMsg struct {
+	rule alerts.Rule
+	err  error
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/models"
+)
+
+// candleWidth is the number of terminal columns each candle takes, including
+// the gap to its neighbour.
+const candleWidth = 2
+
+// Glyphs for a candle cell, by which halves of the cell the body and wick
+// cover.
+const (
+	glyphBodyFull  = "█"
+	glyphBodyUpper = "▀"
+	glyphBodyLower = "▄"
+	glyphWickFull  = "│"
+	glyphWickUpper = "╵"
+	glyphWickLower = "╷"
+	glyphCrossV    = "┊"
+	glyphCrossH    = "┈"
+)
+
+// candleChart draws candles as height rows of half-block glyphs, scaled
+// between the lowest low and highest high. The candle at index focus is
+// highlighted and a crosshair runs through it at its close.
+type candleChart struct {
+	candles []models.Candle
+	focus   int
+	height  int
+}
+
+func (c candleChart) render() []string {
+	if len(c.candles) == 0 || c.height <= 0 {
+		return nil
+	}
+
+	low, high := c.bounds()
+	halves := c.height * 2
+
+	// unit maps a price to a half-row, 0 at the top
+	unit := func(price float64) int {
+		if high <= low {
+			return halves / 2
+		}
+		return int(math.Round((high - price) / (high - low) * float64(halves-1)))
+	}
+
+	crossRow := unit(c.candles[c.focus].Close) / 2
+
+	lines := make([]string, c.height)
+	for row := range lines {
+		var line strings.Builder
+		for i, candle := range c.candles {
+			style := PositiveStyle
+			if candle.Close < candle.Open {
+				style = NegativeStyle
+			}
+			if i == c.focus {
+				style = WarningStyle
+			}
+
+			glyph := candleGlyph(row, unit(candle.High), unit(candle.Low),
+				unit(math.Max(candle.Open, candle.Close)), unit(math.Min(candle.Open, candle.Close)))
+
+			switch {
+			case glyph != " ":
+				line.WriteString(style.Render(glyph))
+			case i == c.focus:
+				line.WriteString(CrosshairStyle.Render(glyphCrossV))
+			case row == crossRow:
+				line.WriteString(CrosshairStyle.Render(glyphCrossH))
+			default:
+				line.WriteString(ValueStyle.Render(" "))
+			}
+
+			// Gap between candles carries the horizontal crosshair
+			gap := " "
+			if row == crossRow {
+				gap = glyphCrossH
+			}
+			line.WriteString(CrosshairStyle.Render(strings.Repeat(gap, candleWidth-1)))
+		}
+		lines[row] = line.String()
+	}
+	return lines
+}
+
+func (c candleChart) bounds() (float64, float64) {
+	low, high := c.candles[0].Low, c.candles[0].High
+	for _, candle := range c.candles[1:] {
+		low = math.Min(low, candle.Low)
+		high = math.Max(high, candle.High)
+	}
+	return low, high
+}
+
+// candleGlyph picks the glyph for one cell of a candle given the half-rows
+// its wick and body span.
+func candleGlyph(row, wickTop, wickBottom, bodyTop, bodyBottom int) string {
+	upper, lower := row*2, row*2+1
+	within := func(unit, from, to int) bool {
+		return unit >= from && unit <= to
+	}
+
+	upperBody, lowerBody := within(upper, bodyTop, bodyBottom), within(lower, bodyTop, bodyBottom)
+	upperWick, lowerWick := within(upper, wickTop, wickBottom), within(lower, wickTop, wickBottom)
+
+	switch {
+	case upperBody && lowerBody:
+		return glyphBodyFull
+	case upperBody:
+		return glyphBodyUpper
+	case lowerBody:
+		return glyphBodyLower
+	case upperWick && lowerWick:
+		return glyphWickFull
+	case upperWick:
+		return glyphWickUpper
+	case lowerWick:
+		return glyphWickLower
+	}
+	return " "
+}
+
+// candleReadout describes the focused candle for the crosshair legend.
+func candleReadout(candle models.Candle, currency, layout string) string {
+	change := 0.0
+	if candle.Open != 0 {
+		change = (candle.Close - candle.Open) / candle.Open * 100
+	}
+	changeText, changeStyle := FormatChange(change)
+
+	field := func(label string, value float64) string {
+		return LabelStyle.Render(label+" ") + ValueStyle.Render(FormatCurrency(value, currency))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		ValueStyle.Render(candle.Time.Format(layout)+"  "),
+		field("O", candle.Open), ValueStyle.Render("  "),
+		field("H", candle.High), ValueStyle.Render("  "),
+		field("L", candle.Low), ValueStyle.Render("  "),
+		field("C", candle.Close), ValueStyle.Render("  "),
+		changeStyle.Render(changeText),
+	)
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"neongecko/models"
+)
+
+// chartRange is a selectable period for the price chart, matching the
+// performance periods shown on the coin cards.
+type chartRange struct {
+	label string
+	days  string // market_chart "days" parameter
+}
+
+var chartRanges = []chartRange{
+	{label: "1d", days: "1"},
+	{label: "7d", days: "7"},
+	{label: "30d", days: "30"},
+	{label: "90d", days: "90"},
+	{label: "1y", days: "365"},
+	{label: "max", days: "max"},
+}
+
+// defaultChartRange is the index of the range shown when a coin is opened.
+const defaultChartRange = 1
+
+// Braille cells are 2 dots wide and 4 dots tall; brailleDots[y][x] is the bit
+// for the dot at column x, row y of a cell.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// renderLineChart draws values as a braille line chart of width x height
+// terminal cells. Values are resampled to fit the width and scaled between
+// their minimum and maximum.
+func renderLineChart(values []float64, width, height int) []string {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	dotsWide, dotsHigh := width*2, height*4
+	samples := resample(values, dotsWide)
+	low, high := bounds(samples)
+
+	// Map each sample to a dot row, 0 at the top
+	rows := make([]int, len(samples))
+	for i, value := range samples {
+		scaled := 0.5
+		if high > low {
+			scaled = (value - low) / (high - low)
+		}
+		rows[i] = int(math.Round(float64(dotsHigh-1) * (1 - scaled)))
+	}
+
+	cells := make([][]rune, height)
+	for i := range cells {
+		cells[i] = make([]rune, width)
+	}
+
+	plot := func(x, y int) {
+		cells[y/4][x/2] |= brailleDots[y%4][x%2]
+	}
+
+	for x, y := range rows {
+		plot(x, y)
+
+		// Fill the vertical gap to the previous sample so the line is unbroken
+		if x > 0 {
+			from, to := rows[x-1], y
+			if from > to {
+				from, to = to, from
+			}
+			for fill := from + 1; fill < to; fill++ {
+				plot(x, fill)
+			}
+		}
+	}
+
+	lines := make([]string, height)
+	for i, row := range cells {
+		var line strings.Builder
+		for _, dots := range row {
+			line.WriteRune(0x2800 + dots)
+		}
+		lines[i] = line.String()
+	}
+	return lines
+}
+
+// resample stretches or shrinks values to exactly n points, taking the
+// nearest sample for each position.
+func resample(values []float64, n int) []float64 {
+	if len(values) == 0 || n <= 0 {
+		return nil
+	}
+
+	samples := make([]float64, n)
+	for i := range samples {
+		index := 0
+		if n > 1 {
+			index = int(math.Round(float64(i) * float64(len(values)-1) / float64(n-1)))
+		}
+		samples[i] = values[index]
+	}
+	return samples
+}
+
+func bounds(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	low, high := values[0], values[0]
+	for _, value := range values[1:] {
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+	return low, high
+}
+
+func historyPrices(history *models.PriceHistory) []float64 {
+	prices := make([]float64, len(history.Prices))
+	for i, point := range history.Prices {
+		prices[i] = point.Price
+	}
+	return prices
+}
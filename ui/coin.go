@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -15,11 +16,30 @@ import (
 )
 
 type CoinModel struct {
-	client       *api.Client
+	client       api.Provider
+	config       *config.Config
+	currency     string
+	currencies   []string // Supported vs_currencies, in cycling order
 	textInput    textinput.Model
 	viewport     viewport.Model
 	coin         *models.Coin
+	history      *models.PriceHistory
+	historyErr   error
+	chartRange   int // Index into chartRanges
+	candleMode   bool
+	candles      []models.Candle
+	candlesErr   error
+	candleFocus  int // Index of the candle under the crosshair
+	candleOffset int // Index of the first visible candle
 	searchResults []models.Coin
+	selected     int // Index of the highlighted search result
+	searching    bool
+	searchErr    error
+	lastQuery    string
+	lastCoinID   string
+	cancel       context.CancelFunc // Cancels the in-flight request, if any
+	requestID    int                // Identifies the latest request; older results are dropped
+	freshness    int64              // Current freshness tick loop
 	loading      bool
 	err          error
 	mode         string // "search" or "display"
@@ -27,7 +47,7 @@ type CoinModel struct {
 	height       int
 }
 
-func NewCoinModel(cfg *config.Config) CoinModel {
+func NewCoinModel(cfg *config.Config, provider api.Provider) CoinModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter coin name or symbol..."
 	ti.Focus()
@@ -38,10 +58,13 @@ func NewCoinModel(cfg *config.Config) CoinModel {
 	vp.Style = BaseStyle
 
 	return CoinModel{
-		client:    api.NewClient(cfg),
-		textInput: ti,
-		viewport:  vp,
-		mode:      "search",
+		client:     provider,
+		config:     cfg,
+		currency:   cfg.GetCurrency(),
+		textInput:  ti,
+		chartRange: defaultChartRange,
+		viewport:   vp,
+		mode:       "search",
 	}
 }
 
@@ -50,9 +73,16 @@ func (m CoinModel) Init() tea.Cmd {
 }
 
 func (m CoinModel) Reset() CoinModel {
+	m = m.Cancel()
 	m.coin = nil
+	m.history = nil
+	m.historyErr = nil
+	m.candles = nil
+	m.candlesErr = nil
 	m.err = nil
 	m.loading = false
+	m.searchResults = nil
+	m.searchErr = nil
 	m.mode = "search"
 	m.textInput.SetValue("")
 	m.textInput.Focus()
@@ -72,7 +102,8 @@ func (m CoinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewport.Width = msg.Width
 		m.viewport.Height = msg.Height - 4 // Leave space for help text
 		
-		return m, nil
+		// Keep the crosshair on screen at the new width
+		return m.scrollCandles(), nil
 
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -80,9 +111,11 @@ func (m CoinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "esc":
 			if m.mode == "display" {
+				m = m.Cancel()
 				m.mode = "search"
 				m.coin = nil
 				m.err = nil
+				m.searchResults = nil
 				m.textInput.SetValue("")
 				m.textInput.Focus()
 				m.textInput.SetCursor(0)
@@ -90,9 +123,21 @@ func (m CoinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "enter":
 			if m.mode == "search" && m.textInput.Value() != "" {
-				m.loading = true
-				query := m.textInput.Value()
-				return m, m.fetchCoinData(query)
+				// Open the highlighted result unless the query has been refined
+				if len(m.searchResults) > 0 && m.textInput.Value() == m.lastQuery {
+					return m.open(m.searchResults[m.selected].ID)
+				}
+				return m.search(m.textInput.Value())
+			}
+		case "up", "ctrl+p":
+			if m.mode == "search" && m.selected > 0 {
+				m.selected--
+				return m, nil
+			}
+		case "down", "ctrl+n":
+			if m.mode == "search" && m.selected < len(m.searchResults)-1 {
+				m.selected++
+				return m, nil
 			}
 		}
 
@@ -104,30 +149,170 @@ func (m CoinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "/", "s":
 				// Switch to search mode for another coin
+				m = m.Cancel()
 				m.mode = "search"
 				m.coin = nil
 				m.err = nil
+				m.searchResults = nil
 				m.textInput.SetValue("")
 				m.textInput.Focus()
 				m.textInput.SetCursor(0)
 				return m, textinput.Blink
+			case "1", "2", "3", "4", "5", "6":
+				m.chartRange = int(msg.String()[0] - '1')
+				return m.loadChart()
+			case "[":
+				m.chartRange = (m.chartRange + len(chartRanges) - 1) % len(chartRanges)
+				return m.loadChart()
+			case "]":
+				m.chartRange = (m.chartRange + 1) % len(chartRanges)
+				return m.loadChart()
+			case "o":
+				m.candleMode = !m.candleMode
+				return m.loadChart()
+			case "left", "h":
+				if m.candleMode && m.candleFocus > 0 {
+					m.candleFocus--
+					m = m.scrollCandles()
+				}
+				return m, nil
+			case "right", "l":
+				if m.candleMode && m.candleFocus < len(m.candles)-1 {
+					m.candleFocus++
+					m = m.scrollCandles()
+				}
+				return m, nil
+			case "f":
+				return m.toggleFavorite()
+			case "c":
+				return m, cycleCurrency(m.currencies, m.currency, 1)
+			case "C":
+				return m, cycleCurrency(m.currencies, m.currency, -1)
 			}
 		}
 
+	case rateLimitTickMsg:
+		// Keep the rate limit countdown ticking until the data arrives
+		if m.loading || m.searching {
+			return m, rateLimitTick()
+		}
+		return m, nil
+
+	case searchResultsMsg:
+		if msg.requestID != m.requestID {
+			return m, nil
+		}
+		m.searching = false
+		m.cancel = nil
+
+		if msg.err != nil {
+			m.searchErr = msg.err
+			if retry, ok := rateLimitRetry(m.searchErr); ok {
+				return m, retry
+			}
+			return m, nil
+		}
+
+		m.searchResults = msg.results
+		m.selected = 0
+
+		// Nothing to choose between
+		if len(m.searchResults) == 1 {
+			return m.open(m.searchResults[0].ID)
+		}
+		return m, nil
+
 	case coinDataMsg:
+		if msg.quiet {
+			// A reread of stale data, which chart requests since don't
+			// supersede; only opening a coin starts another loop
+			if msg.freshness != m.freshness || m.loading {
+				return m, nil
+			}
+			// Keep what's shown if it failed
+			if msg.err == nil {
+				m.coin = msg.coin
+			}
+			m.freshness = nextFreshnessID()
+			return m, freshnessTick(m.freshness)
+		}
+		if msg.requestID != m.requestID {
+			// Result of a cancelled or superseded request
+			return m, nil
+		}
 		m.loading = false
-		m.coin = (*models.Coin)(msg)
+		m.cancel = nil
+
+		if msg.err != nil {
+			m.err = msg.err
+			if retry, ok := rateLimitRetry(m.err); ok {
+				return m, retry
+			}
+			return m, nil
+		}
+
+		m.coin = msg.coin
 		m.mode = "display"
 		
 		// Clear the search input for next search
 		m.textInput.SetValue("")
 		m.textInput.SetCursor(0)
 		
+		m.freshness = nextFreshnessID()
+		m, cmd = m.loadChart()
+		return m, tea.Batch(cmd, freshnessTick(m.freshness))
+
+	case freshnessTickMsg:
+		if msg.id != m.freshness || m.mode != "display" || m.loading || m.coin == nil {
+			return m, nil
+		}
+		if isStale(m.coin.FetchedAt, m.config.GetCacheTTL()) {
+			// Quietly reread; the cache has been refreshing in the background
+			return m, m.refetchCoin(m.freshness, m.coin.ID)
+		}
+		return m, freshnessTick(m.freshness)
+
+	case historyMsg:
+		if msg.requestID != m.requestID {
+			return m, nil
+		}
+		m.cancel = nil
+		m.history = msg.history
+		m.historyErr = msg.err
 		return m, nil
 
-	case errMsg:
-		m.loading = false
-		m.err = error(msg)
+	case candlesMsg:
+		if msg.requestID != m.requestID {
+			return m, nil
+		}
+		m.cancel = nil
+		m.candles = msg.candles
+		m.candlesErr = msg.err
+
+		// Start with the crosshair on the latest candle
+		m.candleFocus = max(len(m.candles)-1, 0)
+		m.candleOffset = 0
+		return m.scrollCandles(), nil
+
+	case CurrenciesMsg:
+		m.currencies = msg
+		return m, nil
+
+	case CurrencyChangedMsg:
+		m.currency = string(msg)
+		if m.mode == "display" && m.coin != nil {
+			return m.open(m.coin.ID)
+		}
+		return m, nil
+
+	case retryMsg:
+		switch {
+		case m.searchErr != nil && m.lastQuery != "":
+			return m.search(m.lastQuery)
+		case m.err != nil && m.lastCoinID != "":
+			m.err = nil
+			return m.open(m.lastCoinID)
+		}
 		return m, nil
 	}
 
@@ -136,11 +321,11 @@ func (m CoinModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m CoinModel) View() string {
 	if m.loading {
-		return BaseStyle.Render("Loading coin data...")
+		return BaseStyle.Render(loadingText(m.client, "Loading coin data..."))
 	}
 
 	if m.err != nil {
-		errorContent := ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n\n" +
+		errorContent := renderError(m.err) + "\n\n" +
 			HelpStyle.Render("Press ESC to go back to search")
 		return BaseStyle.Render(errorContent)
 	}
@@ -158,14 +343,20 @@ func (m CoinModel) View() string {
 func (m CoinModel) renderSearch() string {
 	title := TitleStyle.Render("🔍 Search Cryptocurrency")
 	searchBox := SearchStyle.Render(m.textInput.View())
-	help := HelpStyle.Render("Enter coin name or symbol, then press Enter to search\nPress q to quit")
+	results := m.renderSearchResults()
+
+	helpText := "Enter coin name or symbol, then press Enter to search\nPress q to quit"
+	if len(m.searchResults) > 0 {
+		helpText = "↑/↓: select • Enter: open • edit the query and press Enter to search again"
+	}
+	help := HelpStyle.Render(helpText)
 
 	// Center align all content
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		title,
 		"",
 		searchBox,
-		"",
+		results,
 		help,
 	)
 
@@ -174,16 +365,60 @@ func (m CoinModel) renderSearch() string {
 		Render(content)
 }
 
+// maxVisibleResults bounds how many search results are listed at once.
+const maxVisibleResults = 10
+
+func (m CoinModel) renderSearchResults() string {
+	switch {
+	case m.searching:
+		return ValueStyle.Render(loadingText(m.client, "Searching..."))
+	case m.searchErr != nil:
+		return renderError(m.searchErr)
+	case len(m.searchResults) == 0:
+		return ""
+	}
+
+	// Scroll the window so the selection stays visible
+	start := max(0, m.selected-maxVisibleResults+1)
+	end := min(len(m.searchResults), start+maxVisibleResults)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		coin := m.searchResults[i]
+
+		rank := "   -"
+		if coin.MarketCapRank > 0 {
+			rank = fmt.Sprintf("#%3d", coin.MarketCapRank)
+		}
+		line := fmt.Sprintf("%s  %s (%s)", rank, coin.Name, strings.ToUpper(coin.Symbol))
+
+		if i == m.selected {
+			lines = append(lines, LabelStyle.Render("▸ "+line))
+		} else {
+			lines = append(lines, ValueStyle.Render("  "+line))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, HelpStyle.UnsetPadding().Render(fmt.Sprintf("%d of %d results", m.selected+1, len(m.searchResults))))
+
+	return BoxStyle.Render(strings.Join(lines, "\n"))
+}
+
 func (m CoinModel) renderCoinDisplay() string {
 	if m.coin == nil {
 		return ErrorStyle.Render("No coin data available")
 	}
 
+	if m.candleMode {
+		return m.renderCandleView()
+	}
+
 	// Create grid layout instead of scrollable content
 	gridContent := m.renderCoinGrid()
 	
 	// Help text
-	help := HelpStyle.Render("/,s: search • ESC: home • q: quit")
+	help := HelpStyle.Render(fmt.Sprintf("/,s: search • 1-6,[ ]: chart range • o: candles • f: favorite • c/C: currency (%s) • ESC: home • q: quit", strings.ToUpper(m.currency)))
 	
 	// Center align the content
 	content := lipgloss.JoinVertical(lipgloss.Center,
@@ -235,6 +470,10 @@ func (m CoinModel) renderCoinGrid() string {
 	// Second row: Supply Info (2/3) + Performance (1/3)
 	secondRow := lipgloss.JoinHorizontal(lipgloss.Top, supplyCard, "  ", performanceCard)
 	rows = append(rows, secondRow)
+	rows = append(rows, "")
+
+	// Third row: Price chart spanning both columns
+	rows = append(rows, m.renderChartCard(maxWidth+6))
 
 	return lipgloss.JoinVertical(lipgloss.Center, rows...)
 }
@@ -278,8 +517,15 @@ func (m CoinModel) renderCoinHeader() string {
 	header := fmt.Sprintf("╭─ %s (%s) ─╮", 
 		strings.ToUpper(m.coin.Name), 
 		strings.ToUpper(m.coin.Symbol))
-	
-	return TitleStyle.Render(header)
+	if m.config.IsFavorite(m.coin.ID) {
+		header = "★ " + header
+	}
+
+	badge := freshnessBadge(m.coin.FetchedAt, m.config.GetCacheTTL())
+	if badge == "" {
+		return TitleStyle.Render(header)
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, TitleStyle.Render(header), badge)
 }
 
 func (m CoinModel) renderPriceData() string {
@@ -292,17 +538,17 @@ func (m CoinModel) renderPriceData() string {
 	// Current price
 	lines = append(lines,
 		LabelStyle.Render("Current Price: ") +
-		ValueStyle.Render(FormatCurrency(m.coin.CurrentPrice)))
+		ValueStyle.Render(FormatCurrency(m.coin.CurrentPrice, m.coin.Currency)))
 
 	// Market cap
 	lines = append(lines,
 		LabelStyle.Render("Market Cap: ") +
-		ValueStyle.Render(FormatCurrency(m.coin.MarketCap)))
+		ValueStyle.Render(FormatCurrency(m.coin.MarketCap, m.coin.Currency)))
 
 	// 24h volume
 	lines = append(lines,
 		LabelStyle.Render("24h Volume: ") +
-		ValueStyle.Render(FormatCurrency(m.coin.TotalVolume)))
+		ValueStyle.Render(FormatCurrency(m.coin.TotalVolume, m.coin.Currency)))
 
 	content := strings.Join(lines, "\n")
 	return BoxStyle.Render(content)
@@ -346,13 +592,13 @@ func (m CoinModel) renderHistoricalData() string {
 	athDate := m.coin.AllTimeHighDate.Format("Jan 2, 2006")
 	lines = append(lines,
 		LabelStyle.Render("All-Time High: ") +
-		ValueStyle.Render(fmt.Sprintf("%s (%s)", FormatCurrency(m.coin.AllTimeHigh), athDate)))
+		ValueStyle.Render(fmt.Sprintf("%s (%s)", FormatCurrency(m.coin.AllTimeHigh, m.coin.Currency), athDate)))
 
 	// All-time low
 	atlDate := m.coin.AllTimeLowDate.Format("Jan 2, 2006")
 	lines = append(lines,
 		LabelStyle.Render("All-Time Low: ") +
-		ValueStyle.Render(fmt.Sprintf("%s (%s)", FormatCurrency(m.coin.AllTimeLow), atlDate)))
+		ValueStyle.Render(fmt.Sprintf("%s (%s)", FormatCurrency(m.coin.AllTimeLow, m.coin.Currency), atlDate)))
 
 	content := strings.Join(lines, "\n")
 	return BoxStyle.Render(content)
@@ -399,7 +645,7 @@ func (m CoinModel) renderPriceCard(width int) string {
 	var lines []string
 	lines = append(lines, HeaderStyle.Render("💰 Current Price"))
 	lines = append(lines, "")
-	lines = append(lines, ValueStyle.Render(FormatCurrency(m.coin.CurrentPrice)))
+	lines = append(lines, ValueStyle.Render(FormatCurrency(m.coin.CurrentPrice, m.coin.Currency)))
 	
 	// Add 24h change for context
 	change24h, style24h := FormatChange(m.coin.PriceChangePercentage24h)
@@ -419,15 +665,15 @@ func (m CoinModel) renderMarketCard(width int) string {
 	lines = append(lines, HeaderStyle.Render("📊 Market Data"))
 	lines = append(lines, "")
 	lines = append(lines, 
-		LabelStyle.Render("Market Cap: ") + ValueStyle.Render(FormatCurrency(m.coin.MarketCap)))
+		LabelStyle.Render("Market Cap: ") + ValueStyle.Render(FormatCurrency(m.coin.MarketCap, m.coin.Currency)))
 	lines = append(lines, 
-		LabelStyle.Render("24h Volume: ") + ValueStyle.Render(FormatCurrency(m.coin.TotalVolume)))
+		LabelStyle.Render("24h Volume: ") + ValueStyle.Render(FormatCurrency(m.coin.TotalVolume, m.coin.Currency)))
 	lines = append(lines, "")
 	
 	// Add ATH/ATL data since we have more space
 	athDate := m.coin.AllTimeHighDate.Format("Jan 2, 2006")
 	lines = append(lines, 
-		LabelStyle.Render("All-Time High: ") + ValueStyle.Render(FormatCurrency(m.coin.AllTimeHigh)))
+		LabelStyle.Render("All-Time High: ") + ValueStyle.Render(FormatCurrency(m.coin.AllTimeHigh, m.coin.Currency)))
 	lines = append(lines, 
 		LabelStyle.Render("ATH Date: ") + ValueStyle.Render(athDate))
 
@@ -461,7 +707,7 @@ func (m CoinModel) renderSupplyCard(width int) string {
 	// Add all-time low since we have more space
 	atlDate := m.coin.AllTimeLowDate.Format("Jan 2, 2006")
 	lines = append(lines, 
-		LabelStyle.Render("All-Time Low: ") + ValueStyle.Render(FormatCurrency(m.coin.AllTimeLow)))
+		LabelStyle.Render("All-Time Low: ") + ValueStyle.Render(FormatCurrency(m.coin.AllTimeLow, m.coin.Currency)))
 	lines = append(lines, 
 		LabelStyle.Render("ATL Date: ") + ValueStyle.Render(atlDate))
 
@@ -494,27 +740,369 @@ func (m CoinModel) renderPerformanceCard(width int) string {
 	return BoxStyle.Width(width).Render(content)
 }
 
+func (m CoinModel) renderChartCard(width int) string {
+	if m.coin == nil {
+		return ""
+	}
+
+	var lines []string
+	lines = append(lines, HeaderStyle.Render("🕒 Price History"))
+	lines = append(lines, "")
+
+	// Range selector
+	var ranges []string
+	for i, r := range chartRanges {
+		if i == m.chartRange {
+			ranges = append(ranges, LabelStyle.Render("["+r.label+"]"))
+		} else {
+			ranges = append(ranges, ValueStyle.Render(" "+r.label+" "))
+		}
+	}
+	lines = append(lines, strings.Join(ranges, ValueStyle.Render(" ")))
+	lines = append(lines, "")
+
+	switch {
+	case m.historyErr != nil:
+		lines = append(lines, renderError(m.historyErr))
+	case m.history == nil:
+		lines = append(lines, ValueStyle.Render("Loading chart..."))
+	case len(m.history.Prices) < 2:
+		lines = append(lines, ValueStyle.Render("Not enough data for this range"))
+	default:
+		lines = append(lines, m.renderChart(width-6)...)
+	}
+
+	content := strings.Join(lines, "\n")
+	return BoxStyle.Width(width).Render(content)
+}
+
+// renderChart draws the loaded price history with a price axis on the left
+// and the covered dates underneath.
+func (m CoinModel) renderChart(width int) []string {
+	const chartHeight = 8
+
+	prices := historyPrices(m.history)
+	low, high := bounds(prices)
+	highLabel := FormatCurrency(high, m.history.Currency)
+	lowLabel := FormatCurrency(low, m.history.Currency)
+	axisWidth := max(lipgloss.Width(highLabel), lipgloss.Width(lowLabel)) + 1
+
+	style := PositiveStyle
+	if prices[len(prices)-1] < prices[0] {
+		style = NegativeStyle
+	}
+
+	var lines []string
+	for i, row := range renderLineChart(prices, max(width-axisWidth, 10), chartHeight) {
+		label := ""
+		switch i {
+		case 0:
+			label = highLabel
+		case chartHeight - 1:
+			label = lowLabel
+		}
+		lines = append(lines, LabelStyle.Render(fmt.Sprintf("%*s ", axisWidth-1, label))+style.Render(row))
+	}
+
+	// Date axis
+	layout := "Jan 2, 2006"
+	if chartRanges[m.chartRange].days == "1" {
+		layout = "Jan 2 15:04"
+	}
+	start := m.history.Prices[0].Time.Format(layout)
+	end := m.history.Prices[len(m.history.Prices)-1].Time.Format(layout)
+	gap := max(width-axisWidth-len(start)-len(end), 1)
+	lines = append(lines, ValueStyle.Render(strings.Repeat(" ", axisWidth)+start+strings.Repeat(" ", gap)+end))
+
+	return lines
+}
+
+func (m CoinModel) renderCandleView() string {
+	header := m.renderCoinHeader()
+
+	// Range selector
+	var ranges []string
+	for i, r := range chartRanges {
+		if i == m.chartRange {
+			ranges = append(ranges, LabelStyle.Render("["+r.label+"]"))
+		} else {
+			ranges = append(ranges, ValueStyle.Render(" "+r.label+" "))
+		}
+	}
+
+	var lines []string
+	lines = append(lines, HeaderStyle.Render("📉 Candlesticks"))
+	lines = append(lines, "")
+	lines = append(lines, strings.Join(ranges, ValueStyle.Render(" ")))
+	lines = append(lines, "")
+
+	switch {
+	case m.candlesErr != nil:
+		lines = append(lines, renderError(m.candlesErr))
+	case m.candles == nil:
+		lines = append(lines, ValueStyle.Render("Loading candles..."))
+	case len(m.candles) == 0:
+		lines = append(lines, ValueStyle.Render("No candles for this range"))
+	default:
+		lines = append(lines, m.renderCandles()...)
+	}
+
+	card := BoxStyle.Width(m.width - 2).Render(strings.Join(lines, "\n"))
+	help := HelpStyle.Render("←/→: move crosshair • 1-6,[ ]: range • o: overview • /,s: search • ESC: home")
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		header,
+		card,
+		help,
+	)
+
+	return BaseStyle.
+		Align(lipgloss.Center).
+		Render(content)
+}
+
+// renderCandles draws the visible window of candles with a price axis and
+// the crosshair readout for the focused candle.
+func (m CoinModel) renderCandles() []string {
+	const chartHeight = 14
+
+	visible := m.visibleCandles()
+	chart := candleChart{
+		candles: visible,
+		focus:   m.candleFocus - m.candleOffset,
+		height:  chartHeight,
+	}
+
+	low, high := chart.bounds()
+	highLabel := FormatCurrency(high, m.coin.Currency)
+	lowLabel := FormatCurrency(low, m.coin.Currency)
+	axisWidth := max(lipgloss.Width(highLabel), lipgloss.Width(lowLabel)) + 1
+
+	var lines []string
+	for i, row := range chart.render() {
+		label := ""
+		switch i {
+		case 0:
+			label = highLabel
+		case chartHeight - 1:
+			label = lowLabel
+		}
+		lines = append(lines, LabelStyle.Render(fmt.Sprintf("%*s ", axisWidth-1, label))+row)
+	}
+
+	layout := "Jan 2, 2006"
+	if chartRanges[m.chartRange].days == "1" || chartRanges[m.chartRange].days == "7" {
+		layout = "Jan 2 15:04"
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, candleReadout(m.candles[m.candleFocus], m.coin.Currency, layout))
+	lines = append(lines, ValueStyle.Render(fmt.Sprintf("Candle %d of %d", m.candleFocus+1, len(m.candles))))
+
+	return lines
+}
+
+// candleCapacity is how many candles fit across the candle view.
+func (m CoinModel) candleCapacity() int {
+	// Card border, padding and margin, plus room for the price axis
+	const chrome = 10 + 14
+	return max((m.width-chrome)/candleWidth, 5)
+}
+
+// scrollCandles moves the visible window so the focused candle stays on
+// screen.
+func (m CoinModel) scrollCandles() CoinModel {
+	capacity := m.candleCapacity()
+	if m.candleFocus < m.candleOffset {
+		m.candleOffset = m.candleFocus
+	}
+	if m.candleFocus >= m.candleOffset+capacity {
+		m.candleOffset = m.candleFocus - capacity + 1
+	}
+	return m
+}
+
+func (m CoinModel) visibleCandles() []models.Candle {
+	end := min(m.candleOffset+m.candleCapacity(), len(m.candles))
+	return m.candles[m.candleOffset:end]
+}
+
 // Messages
-type coinDataMsg *models.Coin
+type searchResultsMsg struct {
+	requestID int
+	results   []models.Coin
+	err       error
+}
+
+type coinDataMsg struct {
+	requestID int
+	coin      *models.Coin
+	quiet     bool  // Reread of stale data, not a new coin
+	freshness int64 // Freshness loop that asked for a quiet reread
+	err       error
+}
+
+type historyMsg struct {
+	requestID int
+	history   *models.PriceHistory
+	err       error
+}
+
+type candlesMsg struct {
+	requestID int
+	candles   []models.Candle
+	err       error
+}
+
+// Cancel abandons the in-flight request, if any, so its result is never
+// shown. Call it whenever the user leaves the view.
+func (m CoinModel) Cancel() CoinModel {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	m.requestID++
+	m.loading = false
+	m.searching = false
+	return m
+}
+
+// search cancels any in-flight request and starts looking up query.
+func (m CoinModel) search(query string) (CoinModel, tea.Cmd) {
+	m = m.Cancel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.searching = true
+	m.searchErr = nil
+	m.searchResults = nil
+	m.lastQuery = query
+
+	return m, tea.Batch(m.fetchSearchResults(ctx, m.requestID, query), rateLimitTick())
+}
+
+// Open shows the coin with the given ID, skipping the search step.
+func (m CoinModel) Open(coinID string) (CoinModel, tea.Cmd) {
+	m = m.Reset()
+	return m.open(coinID)
+}
+
+// toggleFavorite adds or removes the displayed coin from the favorites and
+// saves the config.
+func (m CoinModel) toggleFavorite() (CoinModel, tea.Cmd) {
+	if m.coin == nil {
+		return m, nil
+	}
+
+	if m.config.IsFavorite(m.coin.ID) {
+		m.config.RemoveFavorite(m.coin.ID)
+	} else {
+		m.config.AddFavorite(m.coin.ID)
+	}
+
+	if err := config.SaveConfig(m.config); err != nil {
+		m.err = err
+	}
+	return m, nil
+}
+
+// loadChart loads whichever chart is showing for the selected range.
+func (m CoinModel) loadChart() (CoinModel, tea.Cmd) {
+	if m.candleMode {
+		return m.loadCandles()
+	}
+	return m.loadHistory()
+}
 
-func (m CoinModel) fetchCoinData(query string) tea.Cmd {
+// loadCandles cancels any in-flight request and loads OHLC candles of the
+// displayed coin for the selected chart range.
+func (m CoinModel) loadCandles() (CoinModel, tea.Cmd) {
+	m = m.Cancel()
+	m.candles = nil
+	m.candlesErr = nil
+	if m.coin == nil {
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	return m, m.fetchCandles(ctx, m.requestID, m.coin.ID, chartRanges[m.chartRange].days)
+}
+
+// loadHistory cancels any in-flight request and loads the price history of
+// the displayed coin for the selected chart range.
+func (m CoinModel) loadHistory() (CoinModel, tea.Cmd) {
+	m = m.Cancel()
+	m.history = nil
+	m.historyErr = nil
+	if m.coin == nil {
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	return m, m.fetchHistory(ctx, m.requestID, m.coin.ID, chartRanges[m.chartRange].days)
+}
+
+// open cancels any in-flight request and loads the coin with the given ID.
+func (m CoinModel) open(coinID string) (CoinModel, tea.Cmd) {
+	m = m.Cancel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.loading = true
+	m.lastCoinID = coinID
+
+	return m, tea.Batch(m.fetchCoinByID(ctx, m.requestID, coinID), rateLimitTick())
+}
+
+func (m CoinModel) fetchSearchResults(ctx context.Context, requestID int, query string) tea.Cmd {
 	return func() tea.Msg {
-		// First try to search for the coin
-		searchResults, err := m.client.SearchCoins(query)
+		searchResults, err := m.client.SearchCoinsContext(ctx, query)
 		if err != nil {
-			return errMsg(err)
+			return searchResultsMsg{requestID: requestID, err: err}
 		}
 
 		if len(searchResults) == 0 {
-			return errMsg(fmt.Errorf("no coins found for '%s'", query))
+			return searchResultsMsg{requestID: requestID, err: &api.NotFoundError{Resource: fmt.Sprintf("coins matching '%s'", query)}}
 		}
 
-		// Get detailed data for the first result
-		coinData, err := m.client.GetCoinData(searchResults[0].ID)
+		return searchResultsMsg{requestID: requestID, results: searchResults}
+	}
+}
+
+func (m CoinModel) fetchCoinByID(ctx context.Context, requestID int, coinID string) tea.Cmd {
+	return func() tea.Msg {
+		coinData, err := m.client.GetCoinDataContext(ctx, coinID, m.currency)
 		if err != nil {
-			return errMsg(err)
+			return coinDataMsg{requestID: requestID, err: err}
 		}
 
-		return coinDataMsg(coinData)
+		return coinDataMsg{requestID: requestID, coin: coinData}
+	}
+}
+
+// refetchCoin rereads the displayed coin for the freshness loop without
+// cancelling the chart request or showing the loading screen.
+func (m CoinModel) refetchCoin(freshness int64, coinID string) tea.Cmd {
+	return func() tea.Msg {
+		coinData, err := m.client.GetCoinDataContext(context.Background(), coinID, m.currency)
+		return coinDataMsg{coin: coinData, quiet: true, freshness: freshness, err: err}
+	}
+}
+
+func (m CoinModel) fetchHistory(ctx context.Context, requestID int, coinID string, days string) tea.Cmd {
+	return func() tea.Msg {
+		history, err := m.client.GetPriceHistoryContext(ctx, coinID, m.currency, days)
+		return historyMsg{requestID: requestID, history: history, err: err}
+	}
+}
+
+func (m CoinModel) fetchCandles(ctx context.Context, requestID int, coinID string, days string) tea.Cmd {
+	return func() tea.Msg {
+		candles, err := m.client.GetOHLCContext(ctx, coinID, m.currency, days)
+		return candlesMsg{requestID: requestID, candles: candles, err: err}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"testing"
+
+	"neongecko/models"
+)
+
+func TestCoinKeepsFreshnessLoopAfterChartChange(t *testing.T) {
+	m := CoinModel{mode: "display", coin: &models.Coin{ID: "bitcoin"}, freshness: nextFreshnessID()}
+	freshness := m.freshness
+	// Switching the chart range while the reread is in flight
+	m.requestID++
+
+	updated, cmd := m.Update(coinDataMsg{coin: &models.Coin{ID: "bitcoin", CurrentPrice: 1}, quiet: true, freshness: freshness})
+
+	coin := updated.(CoinModel)
+	if cmd == nil || coin.freshness == freshness {
+		t.Error("quiet reread after a chart change didn't schedule the next freshness tick")
+	}
+	if coin.coin.CurrentPrice != 1 {
+		t.Errorf("coin price = %v, want the reread's 1", coin.coin.CurrentPrice)
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"neongecko/api"
+)
+
+// CurrenciesMsg carries the vs_currencies the provider supports. It is
+// shared by every view, so the program forwards it to all of them.
+type CurrenciesMsg []string
+
+// CurrencyChangedMsg switches every view to a new vs_currency.
+type CurrencyChangedMsg string
+
+// preferredCurrencies are offered first when cycling, in this order; the
+// rest of the supported list follows alphabetically.
+var preferredCurrencies = []string{
+	"usd", "eur", "gbp", "jpy", "cny", "krw", "inr", "cad", "aud", "chf", "brl", "btc", "eth", "sats",
+}
+
+// FetchCurrencies loads the supported vs_currencies from provider.
+func FetchCurrencies(provider api.Provider) tea.Cmd {
+	return func() tea.Msg {
+		currencies, err := provider.GetSupportedCurrenciesContext(context.Background())
+		if err != nil {
+			// Cycling falls back to the preferred list
+			return nil
+		}
+		return CurrenciesMsg(orderCurrencies(currencies))
+	}
+}
+
+// orderCurrencies puts the preferred currencies first, followed by the
+// remaining codes in alphabetical order.
+func orderCurrencies(currencies []string) []string {
+	var ordered []string
+	for _, currency := range preferredCurrencies {
+		if slices.Contains(currencies, currency) {
+			ordered = append(ordered, currency)
+		}
+	}
+
+	var rest []string
+	for _, currency := range currencies {
+		currency = strings.ToLower(currency)
+		if !slices.Contains(preferredCurrencies, currency) {
+			rest = append(rest, currency)
+		}
+	}
+	slices.Sort(rest)
+
+	return append(ordered, rest...)
+}
+
+// cycleCurrency switches to the currency step places from current in
+// currencies, wrapping around at either end.
+func cycleCurrency(currencies []string, current string, step int) tea.Cmd {
+	if len(currencies) == 0 {
+		currencies = preferredCurrencies
+	}
+
+	index := slices.Index(currencies, current)
+	if index < 0 {
+		index = 0
+		if step > 0 {
+			step--
+		}
+	}
+
+	next := currencies[((index+step)%len(currencies)+len(currencies))%len(currencies)]
+	return func() tea.Msg {
+		return CurrencyChangedMsg(next)
+	}
+}
+
+// currencyFormat describes how amounts in a vs_currency are written.
+type currencyFormat struct {
+	symbol   string
+	suffix   bool // Symbol goes after the amount
+	decimals int  // Sub-unit precision
+}
+
+var currencyFormats = map[string]currencyFormat{
+	"usd":  {symbol: "$", decimals: 2},
+	"eur":  {symbol: "€", decimals: 2},
+	"gbp":  {symbol: "£", decimals: 2},
+	"jpy":  {symbol: "¥", decimals: 0},
+	"cny":  {symbol: "CN¥", decimals: 2},
+	"krw":  {symbol: "₩", decimals: 0},
+	"inr":  {symbol: "₹", decimals: 2},
+	"rub":  {symbol: "₽", decimals: 2},
+	"try":  {symbol: "₺", decimals: 2},
+	"ngn":  {symbol: "₦", decimals: 2},
+	"php":  {symbol: "₱", decimals: 2},
+	"uah":  {symbol: "₴", decimals: 2},
+	"vnd":  {symbol: "₫", decimals: 0},
+	"idr":  {symbol: "Rp", decimals: 0},
+	"ils":  {symbol: "₪", decimals: 2},
+	"brl":  {symbol: "R$", decimals: 2},
+	"aud":  {symbol: "A$", decimals: 2},
+	"cad":  {symbol: "C$", decimals: 2},
+	"nzd":  {symbol: "NZ$", decimals: 2},
+	"hkd":  {symbol: "HK$", decimals: 2},
+	"sgd":  {symbol: "S$", decimals: 2},
+	"mxn":  {symbol: "MX$", decimals: 2},
+	"twd":  {symbol: "NT$", decimals: 0},
+	"clp":  {symbol: "CLP$", decimals: 0},
+	"chf":  {symbol: " CHF", suffix: true, decimals: 2},
+	"btc":  {symbol: "₿", decimals: 8},
+	"eth":  {symbol: "Ξ", decimals: 6},
+	"sats": {symbol: " sats", suffix: true, decimals: 0},
+	"bits": {symbol: " bits", suffix: true, decimals: 2},
+}
+
+// formatFor returns the format for currency; unknown codes are written
+// after the amount in upper case.
+func formatFor(currency string) currencyFormat {
+	currency = strings.ToLower(currency)
+	if format, ok := currencyFormats[currency]; ok {
+		return format
+	}
+	if currency == "" {
+		return currencyFormats["usd"]
+	}
+	return currencyFormat{symbol: " " + strings.ToUpper(currency), suffix: true, decimals: 2}
+}
+
+// precision returns how many decimals to show for value, adding digits for
+// amounts that would otherwise round to zero (e.g. altcoins priced in BTC).
+func (f currencyFormat) precision(value float64) int {
+	value = math.Abs(value)
+	if value == 0 || value >= math.Pow10(-f.decimals) {
+		return f.decimals
+	}
+
+	// Keep three significant digits
+	decimals := int(-math.Floor(math.Log10(value))) + 2
+	return min(decimals, 12)
+}
+
+func (f currencyFormat) apply(amount string) string {
+	if f.suffix {
+		return amount + f.symbol
+	}
+	return f.symbol + amount
+}
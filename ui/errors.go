@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"neongecko/api"
+)
+
+// defaultRetryDelay is used when the API rate limits us without saying for
+// how long.
+const defaultRetryDelay = 30 * time.Second
+
+// retryMsg asks the view to repeat the request that was rate limited.
+type retryMsg struct{}
+
+// rateLimitRetry schedules a retry if err is a rate limit error.
+func rateLimitRetry(err error) (tea.Cmd, bool) {
+	var rateLimitErr *api.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return nil, false
+	}
+
+	wait := rateLimitErr.RetryAfter()
+	if wait <= 0 {
+		wait = defaultRetryDelay
+	}
+
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return retryMsg{}
+	}), true
+}
+
+// renderError describes an API error according to what went wrong, with a
+// hint on what the user can do about it.
+func renderError(err error) string {
+	var (
+		notFoundErr  *api.NotFoundError
+		rateLimitErr *api.RateLimitError
+		upstreamErr  *api.UpstreamError
+		decodeErr    *api.DecodeError
+		networkErr   *api.NetworkError
+	)
+
+	var message, hint string
+	style := ErrorStyle
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		message = fmt.Sprintf("Not found: %s", notFoundErr.Resource)
+		hint = "Check the spelling, or search by the coin's full name"
+		style = WarningStyle
+	case errors.As(err, &rateLimitErr):
+		message = "Rate limited by the API"
+		if wait := rateLimitErr.RetryAfter(); wait > 0 {
+			hint = fmt.Sprintf("Retrying automatically in %ds", int(math.Ceil(wait.Seconds())))
+		} else {
+			hint = "Retrying automatically shortly"
+		}
+		style = WarningStyle
+	case errors.As(err, &upstreamErr):
+		message = fmt.Sprintf("The API returned HTTP %d", upstreamErr.StatusCode)
+		hint = "The service may be having trouble, try again later"
+		if upstreamErr.Body != "" {
+			hint = upstreamErr.Body + "\n" + hint
+		}
+	case errors.As(err, &decodeErr):
+		message = "Unexpected response from the API"
+		hint = decodeErr.Err.Error()
+	case errors.As(err, &networkErr):
+		message = "Can't reach the API"
+		hint = "Check your internet connection and try again"
+	default:
+		message = fmt.Sprintf("Error: %v", err)
+	}
+
+	return style.Render(message) + "\n\n" + HelpStyle.Render(hint)
+}
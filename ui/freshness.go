@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// freshnessInterval is how often a view re-renders its "updated ... ago"
+// badge and rereads stale data.
+const freshnessInterval = 15 * time.Second
+
+// freshnessTickMsg keeps a freshness badge current. The id ties the tick to
+// the loop that scheduled it, so a refetch doesn't leave two loops running.
+type freshnessTickMsg struct {
+	id int64
+}
+
+var freshnessIDs atomic.Int64
+
+// nextFreshnessID returns an id unique across every view, since ticks are
+// delivered to whichever view is on screen.
+func nextFreshnessID() int64 {
+	return freshnessIDs.Add(1)
+}
+
+func freshnessTick(id int64) tea.Cmd {
+	return tea.Tick(freshnessInterval, func(time.Time) tea.Msg {
+		return freshnessTickMsg{id: id}
+	})
+}
+
+// isStale reports whether data fetched at fetchedAt has outlived the cache
+// TTL. Data of unknown age is never stale.
+func isStale(fetchedAt time.Time, ttl time.Duration) bool {
+	return !fetchedAt.IsZero() && time.Since(fetchedAt) > ttl
+}
+
+// freshnessBadge renders how long ago data was fetched, flagged once it is
+// older than the cache TTL and a background refresh is pending.
+func freshnessBadge(fetchedAt time.Time, ttl time.Duration) string {
+	if fetchedAt.IsZero() {
+		return ""
+	}
+
+	badge := HelpStyle.UnsetPadding().Render("updated " + formatAge(time.Since(fetchedAt)))
+	if isStale(fetchedAt, ttl) {
+		badge += HelpStyle.UnsetPadding().Render(" ") + WarningStyle.Render("stale")
+	}
+	return badge
+}
+
+// formatAge renders a duration the way people say it: "just now", "3m ago".
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	}
+}
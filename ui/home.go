@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,23 +15,29 @@ import (
 )
 
 type HomeModel struct {
-	client     *api.Client
+	client     api.Provider
+	currency   string
+	currencies []string // Supported vs_currencies, in cycling order
 	globalData *models.GlobalData
+	cacheTTL   time.Duration // Age at which globalData is shown as stale
+	freshness  int64         // Current freshness tick loop
 	loading    bool
 	err        error
 	width      int
 	height     int
 }
 
-func NewHomeModel(cfg *config.Config) HomeModel {
+func NewHomeModel(cfg *config.Config, provider api.Provider) HomeModel {
 	return HomeModel{
-		client:  api.NewClient(cfg),
-		loading: true,
+		client:   provider,
+		currency: cfg.GetCurrency(),
+		cacheTTL: cfg.GetCacheTTL(),
+		loading:  true,
 	}
 }
 
 func (m HomeModel) Init() tea.Cmd {
-	return m.fetchGlobalData
+	return tea.Batch(m.fetchGlobalData, rateLimitTick())
 }
 
 func (m HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -45,7 +53,11 @@ func (m HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "r":
 			m.loading = true
-			return m, m.fetchGlobalData
+			return m, tea.Batch(m.fetchGlobalData, rateLimitTick())
+		case "c":
+			return m, cycleCurrency(m.currencies, m.currency, 1)
+		case "C":
+			return m, cycleCurrency(m.currencies, m.currency, -1)
 		case "/", "s":
 			// TODO: Switch to search view
 			return m, nil
@@ -54,15 +66,58 @@ func (m HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+	case rateLimitTickMsg:
+		// Keep the rate limit countdown ticking until the data arrives
+		if m.loading {
+			return m, rateLimitTick()
+		}
+		return m, nil
+
+	case CurrenciesMsg:
+		m.currencies = msg
+		return m, nil
+
+	case CurrencyChangedMsg:
+		m.currency = string(msg)
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.fetchGlobalData, rateLimitTick())
+
 	case globalDataMsg:
+		if msg.Currency != m.currency {
+			// Fetched before the currency was switched
+			return m, nil
+		}
 		m.loading = false
 		m.globalData = (*models.GlobalData)(msg)
-		return m, nil
+		m.freshness = nextFreshnessID()
+		return m, freshnessTick(m.freshness)
+
+	case freshnessTickMsg:
+		if msg.id != m.freshness || m.loading || m.globalData == nil {
+			return m, nil
+		}
+		if isStale(m.globalData.FetchedAt, m.cacheTTL) {
+			// Quietly reread; the cache has been refreshing in the background
+			return m, m.fetchGlobalData
+		}
+		return m, freshnessTick(m.freshness)
 
 	case errMsg:
 		m.loading = false
 		m.err = error(msg)
+		if retry, ok := rateLimitRetry(m.err); ok {
+			return m, retry
+		}
 		return m, nil
+
+	case retryMsg:
+		if m.err == nil {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, tea.Batch(m.fetchGlobalData, rateLimitTick())
 	}
 
 	return m, nil
@@ -72,13 +127,13 @@ func (m HomeModel) View() string {
 	if m.loading {
 		return BaseStyle.
 			Align(lipgloss.Center).
-			Render("Loading global crypto data...")
+			Render(loadingText(m.client, "Loading global crypto data..."))
 	}
 
 	if m.err != nil {
 		return BaseStyle.
 			Align(lipgloss.Center).
-			Render(ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+			Render(renderError(m.err))
 	}
 
 	if m.globalData == nil {
@@ -120,7 +175,7 @@ func (m HomeModel) renderMarketData() string {
 	// Total Market Cap
 	lines = append(lines, 
 		LabelStyle.Render("Total Market Cap: ") + 
-		ValueStyle.Render(FormatCurrency(m.globalData.TotalMarketCap)))
+		ValueStyle.Render(FormatCurrency(m.globalData.TotalMarketCap, m.globalData.Currency)))
 
 	// Market Cap Change
 	changeText, changeStyle := FormatChange(m.globalData.MarketCapChangePercentage24h)
@@ -131,7 +186,11 @@ func (m HomeModel) renderMarketData() string {
 	// Total Volume
 	lines = append(lines,
 		LabelStyle.Render("24h Volume: ") +
-		ValueStyle.Render(FormatCurrency(m.globalData.TotalVolume)))
+		ValueStyle.Render(FormatCurrency(m.globalData.TotalVolume, m.globalData.Currency)))
+
+	if badge := freshnessBadge(m.globalData.FetchedAt, m.cacheTTL); badge != "" {
+		lines = append(lines, "", badge)
+	}
 
 	content := strings.Join(lines, "\n")
 	return BoxStyle.Render(content)
@@ -141,7 +200,12 @@ func (m HomeModel) renderHelp() string {
 	helpText := []string{
 		"Navigation:",
 		"• / or s - Search for a coin",
+		"• m - Top markets",
+		"• w - Watchlist",
+		"• p - Portfolio",
+		"• a - Price alerts",
 		"• r - Refresh data", 
+		fmt.Sprintf("• c/C - Switch currency (%s)", strings.ToUpper(m.currency)),
 		"• h - Show help",
 		"• q or Ctrl+C - Quit",
 	}
@@ -154,7 +218,7 @@ type globalDataMsg *models.GlobalData
 type errMsg error
 
 func (m HomeModel) fetchGlobalData() tea.Msg {
-	data, err := m.client.GetGlobalData()
+	data, err := m.client.GetGlobalDataContext(context.Background(), m.currency)
 	if err != nil {
 		return errMsg(err)
 	}
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/models"
+)
+
+// marketsPerPage is how many coins each page of the markets table holds.
+const marketsPerPage = 50
+
+// marketSort is a column the markets table can be ordered by. Columns with
+// an API order are sorted by CoinGecko across all pages; the rest are sorted
+// locally within the current page.
+type marketSort struct {
+	label    string
+	apiOrder string // CoinGecko order for descending; "" to sort locally
+	value    func(coin models.Coin) float64
+}
+
+var marketSorts = []marketSort{
+	{label: "Rank", apiOrder: "market_cap_desc", value: func(coin models.Coin) float64 { return coin.MarketCap }},
+	{label: "Price", value: func(coin models.Coin) float64 { return coin.CurrentPrice }},
+	{label: "24h", value: func(coin models.Coin) float64 { return coin.PriceChangePercentage24h }},
+	{label: "7d", value: func(coin models.Coin) float64 { return coin.PriceChangePercentage7d }},
+	{label: "30d", value: func(coin models.Coin) float64 { return coin.PriceChangePercentage30d }},
+	{label: "Market Cap", apiOrder: "market_cap_desc", value: func(coin models.Coin) float64 { return coin.MarketCap }},
+	{label: "Volume", apiOrder: "volume_desc", value: func(coin models.Coin) float64 { return coin.TotalVolume }},
+}
+
+var marketsColumns = []coinColumn{
+	rankColumn,
+	nameColumn,
+	priceColumn,
+	change24hColumn,
+	change7dColumn,
+	change30dColumn,
+	marketCapColumn,
+	volumeColumn,
+}
+
+type MarketsModel struct {
+	client     api.Provider
+	currency   string
+	currencies []string // Supported vs_currencies, in cycling order
+	coins      []models.Coin
+	page       int
+	sortBy     int  // Index into marketSorts
+	ascending  bool // Reverse the natural (descending) order
+	selected   int
+	loading    bool
+	err        error
+	width      int
+	height     int
+}
+
+func NewMarketsModel(cfg *config.Config, provider api.Provider) MarketsModel {
+	return MarketsModel{
+		client:   provider,
+		currency: cfg.GetCurrency(),
+		page:     1,
+	}
+}
+
+func (m MarketsModel) Init() tea.Cmd {
+	return m.fetchMarkets()
+}
+
+// lastPage reports whether the page shown is the last, which CoinGecko only
+// tells us by returning fewer coins than asked for.
+func (m MarketsModel) lastPage() bool {
+	return !m.loading && m.err == nil && len(m.coins) < marketsPerPage
+}
+
+// Reload fetches the current page again.
+func (m MarketsModel) Reload() (MarketsModel, tea.Cmd) {
+	m.loading = true
+	m.err = nil
+	return m, tea.Batch(m.fetchMarkets(), rateLimitTick())
+}
+
+func (m MarketsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return m.Reload()
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case "down", "j":
+			if m.selected < len(m.coins)-1 {
+				m.selected++
+			}
+			return m, nil
+		case "enter":
+			if len(m.coins) > 0 {
+				coinID := m.coins[m.selected].ID
+				return m, func() tea.Msg { return OpenCoinMsg(coinID) }
+			}
+		case "right", "n":
+			if m.lastPage() {
+				return m, nil
+			}
+			m.page++
+			m.selected = 0
+			return m.Reload()
+		case "left", "p":
+			if m.page > 1 {
+				m.page--
+				m.selected = 0
+				return m.Reload()
+			}
+		case ">":
+			return m.sortOn((m.sortBy+1)%len(marketSorts), false)
+		case "<":
+			return m.sortOn((m.sortBy+len(marketSorts)-1)%len(marketSorts), false)
+		case "i":
+			return m.sortOn(m.sortBy, !m.ascending)
+		case "c":
+			return m, cycleCurrency(m.currencies, m.currency, 1)
+		case "C":
+			return m, cycleCurrency(m.currencies, m.currency, -1)
+		}
+
+	case rateLimitTickMsg:
+		// Keep the rate limit countdown ticking until the data arrives
+		if m.loading {
+			return m, rateLimitTick()
+		}
+		return m, nil
+
+	case marketsMsg:
+		if msg.currency != m.currency || msg.page != m.page || msg.order != m.apiOrder() {
+			// Fetched for a page, order or currency no longer shown
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.coins = msg.coins
+			m.sortCoins()
+			m.selected = min(m.selected, max(len(m.coins)-1, 0))
+		}
+		if retry, ok := rateLimitRetry(m.err); ok {
+			return m, retry
+		}
+		return m, nil
+
+	case retryMsg:
+		if m.err == nil {
+			return m, nil
+		}
+		return m.Reload()
+
+	case CurrenciesMsg:
+		m.currencies = msg
+		return m, nil
+
+	case CurrencyChangedMsg:
+		m.currency = string(msg)
+		if m.coins == nil && !m.loading {
+			// Never opened; the next visit loads in the new currency
+			return m, nil
+		}
+		return m.Reload()
+	}
+
+	return m, nil
+}
+
+// sortOn switches the sort column and direction, refetching when CoinGecko
+// has to apply the new order across pages.
+func (m MarketsModel) sortOn(sortBy int, ascending bool) (MarketsModel, tea.Cmd) {
+	previousOrder := m.apiOrder()
+	m.sortBy = sortBy
+	m.ascending = ascending
+	m.selected = 0
+
+	if m.apiOrder() != previousOrder {
+		m.page = 1
+		return m.Reload()
+	}
+
+	m.sortCoins()
+	return m, nil
+}
+
+// apiOrder is the CoinGecko order for the current sort; locally sorted
+// columns keep the default market cap order.
+func (m MarketsModel) apiOrder() string {
+	order := marketSorts[m.sortBy].apiOrder
+	if order == "" {
+		return "market_cap_desc"
+	}
+	if m.ascending {
+		return strings.TrimSuffix(order, "_desc") + "_asc"
+	}
+	return order
+}
+
+func (m *MarketsModel) sortCoins() {
+	value := marketSorts[m.sortBy].value
+	sort.SliceStable(m.coins, func(i, j int) bool {
+		if m.ascending {
+			return value(m.coins[i]) < value(m.coins[j])
+		}
+		return value(m.coins[i]) > value(m.coins[j])
+	})
+}
+
+func (m MarketsModel) View() string {
+	if m.loading {
+		return BaseStyle.
+			Align(lipgloss.Center).
+			Render(loadingText(m.client, "Loading markets..."))
+	}
+
+	title := TitleStyle.Render("🏦 Top Markets")
+
+	direction := "↓"
+	if m.ascending {
+		direction = "↑"
+	}
+	scope := ""
+	if marketSorts[m.sortBy].apiOrder == "" {
+		scope = " (within page)"
+	}
+	status := LabelStyle.Render("Sort: ") +
+		ValueStyle.Render(fmt.Sprintf("%s %s%s", marketSorts[m.sortBy].label, direction, scope)) +
+		LabelStyle.Render("   Page: ") +
+		ValueStyle.Render(fmt.Sprint(m.page))
+
+	var body string
+	if m.err != nil {
+		body = renderError(m.err)
+	} else {
+		body = BoxStyle.Render(strings.Join(m.visibleRows(), "\n"))
+	}
+
+	help := HelpStyle.Render(fmt.Sprintf("↑/↓: select • Enter: open • ←/→: page • </>: sort • i: invert • r: refresh • c/C: currency (%s) • ESC: home",
+		strings.ToUpper(m.currency)))
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		status,
+		body,
+		help,
+	)
+
+	return BaseStyle.
+		Align(lipgloss.Center).
+		Render(content)
+}
+
+// visibleRows renders the header and a window of rows around the selection
+// that fits the terminal height.
+func (m MarketsModel) visibleRows() []string {
+	rows := renderCoinTable(marketsColumns, m.coins, m.selected)
+	header, body := rows[0], rows[1:]
+
+	// Title, status, box chrome and help take about 20 lines
+	capacity := max(m.height-20, 5)
+	start := max(0, m.selected-capacity+1)
+	end := min(len(body), start+capacity)
+
+	return append([]string{header}, body[start:end]...)
+}
+
+// Messages
+type marketsMsg struct {
+	currency string
+	page     int
+	order    string
+	coins    []models.Coin
+	err      error
+}
+
+func (m MarketsModel) fetchMarkets() tea.Cmd {
+	query := api.MarketsQuery{
+		Currency: m.currency,
+		Order:    m.apiOrder(),
+		Page:     m.page,
+		PerPage:  marketsPerPage,
+	}
+
+	return func() tea.Msg {
+		coins, err := m.client.GetMarketsContext(context.Background(), query)
+		return marketsMsg{currency: query.Currency, page: query.Page, order: query.Order, coins: coins, err: err}
+	}
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"neongecko/models"
+)
+
+func TestMarketsStopsPagingAfterShortPage(t *testing.T) {
+	for _, tt := range []struct {
+		coins    int
+		wantPage int
+	}{
+		{coins: marketsPerPage, wantPage: 3},
+		{coins: marketsPerPage - 1, wantPage: 2},
+		{coins: 0, wantPage: 2},
+	} {
+		m := MarketsModel{page: 2, coins: make([]models.Coin, tt.coins)}
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+		if page := updated.(MarketsModel).page; page != tt.wantPage {
+			t.Errorf("after a page of %d coins, page = %d, want %d", tt.coins, page, tt.wantPage)
+		}
+	}
+}
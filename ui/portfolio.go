@@ -0,0 +1,600 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/portfolio"
+)
+
+var positionColumns = []tableColumn[portfolio.Position]{
+	{title: "Coin", width: 24, left: true, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		return fmt.Sprintf("%s (%s)", p.Coin.Name, strings.ToUpper(p.Coin.Symbol)), ValueStyle
+	}},
+	{title: "Quantity", width: 12, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		return formatQuantity(p.Quantity), ValueStyle
+	}},
+	{title: "Avg Cost", width: 12, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		return FormatCurrency(p.AverageCost(), p.Coin.Currency), ValueStyle
+	}},
+	{title: "Price", width: 12, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		if !p.Priced {
+			return "n/a", HelpStyle
+		}
+		return FormatCurrency(p.Coin.CurrentPrice, p.Coin.Currency), ValueStyle
+	}},
+	{title: "Value", width: 12, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		if !p.Priced {
+			return "n/a", HelpStyle
+		}
+		return FormatCurrency(p.Value, p.Coin.Currency), ValueStyle
+	}},
+	{title: "Unrealized", width: 12, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		if !p.Priced {
+			return "n/a", HelpStyle
+		}
+		return formatPnL(p.UnrealizedPnL, p.Coin.Currency)
+	}},
+	{title: "Realized", width: 12, cell: func(p portfolio.Position) (string, lipgloss.Style) {
+		return formatPnL(p.RealizedPnL, p.Coin.Currency)
+	}},
+}
+
+// transactionColumns renders ledger rows; amounts are in the ledger currency.
+func transactionColumns(currency string) []tableColumn[portfolio.Transaction] {
+	return []tableColumn[portfolio.Transaction]{
+		{title: "Date", width: 10, left: true, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			return tx.Date.Format("2006-01-02"), ValueStyle
+		}},
+		{title: "Type", width: 12, left: true, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			if tx.Type == portfolio.Sell || tx.Type == portfolio.TransferOut {
+				return transactionLabel(tx.Type), NegativeStyle
+			}
+			return transactionLabel(tx.Type), PositiveStyle
+		}},
+		{title: "Coin", width: 16, left: true, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			return tx.CoinID, ValueStyle
+		}},
+		{title: "Quantity", width: 12, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			return formatQuantity(tx.Quantity), ValueStyle
+		}},
+		{title: "Price", width: 12, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			return FormatCurrency(tx.Price, currency), ValueStyle
+		}},
+		{title: "Fee", width: 10, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			return FormatCurrency(tx.Fee, currency), ValueStyle
+		}},
+		{title: "Note", width: 20, left: true, cell: func(tx portfolio.Transaction) (string, lipgloss.Style) {
+			return tx.Note, HelpStyle
+		}},
+	}
+}
+
+// disposalColumns renders realized gains; amounts are in the ledger currency.
+func disposalColumns(currency string) []tableColumn[portfolio.Disposal] {
+	return []tableColumn[portfolio.Disposal]{
+		{title: "Coin", width: 16, left: true, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return d.CoinID, ValueStyle
+		}},
+		{title: "Quantity", width: 12, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return formatQuantity(d.Quantity), ValueStyle
+		}},
+		{title: "Acquired", width: 10, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return d.Acquired.Format("2006-01-02"), ValueStyle
+		}},
+		{title: "Sold", width: 10, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return d.Sold.Format("2006-01-02"), ValueStyle
+		}},
+		{title: "Proceeds", width: 12, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return FormatCurrency(d.Proceeds, currency), ValueStyle
+		}},
+		{title: "Basis", width: 12, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return FormatCurrency(d.Basis, currency), ValueStyle
+		}},
+		{title: "Gain", width: 12, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			return formatPnL(d.Gain, currency)
+		}},
+		{title: "Term", width: 5, left: true, cell: func(d portfolio.Disposal) (string, lipgloss.Style) {
+			if d.LongTerm {
+				return "Long", ValueStyle
+			}
+			return "Short", ValueStyle
+		}},
+	}
+}
+
+// portfolioMode is which table the portfolio view shows.
+type portfolioMode int
+
+const (
+	holdingsMode portfolioMode = iota
+	transactionsMode
+	gainsMode
+)
+
+type PortfolioModel struct {
+	client        api.Provider
+	ledger        *portfolio.Ledger
+	ledgerErr     error // Loading or saving the ledger failed
+	positions     []portfolio.Position
+	revision      int // Bumped on every ledger change to drop stale valuations
+	mode          portfolioMode
+	reports       []portfolio.YearReport // Realized gains per year, oldest first
+	year          int                    // Index into reports
+	notice        string                 // Result of the last export
+	selected      int
+	form          *transactionForm
+	confirmDelete bool
+	loading       bool
+	err           error
+	width         int
+	height        int
+}
+
+// NewPortfolioModel loads the ledger from disk. New ledgers are kept in the
+// configured currency; an existing ledger keeps the currency it was
+// recorded in.
+func NewPortfolioModel(cfg *config.Config, provider api.Provider) PortfolioModel {
+	ledger, err := portfolio.LoadLedger(cfg.GetCurrency())
+	return PortfolioModel{
+		client:    provider,
+		ledger:    ledger,
+		ledgerErr: err,
+	}
+}
+
+func (m PortfolioModel) Init() tea.Cmd {
+	if m.ledger == nil {
+		return nil
+	}
+	return m.valuate()
+}
+
+// Reload prices the holdings again.
+func (m PortfolioModel) Reload() (PortfolioModel, tea.Cmd) {
+	if m.ledger == nil {
+		return m, nil
+	}
+	m.revision++
+	m.loading = true
+	m.err = nil
+	m.refreshReports()
+	return m, tea.Batch(m.valuate(), rateLimitTick())
+}
+
+// Editing reports whether the view is capturing keys for a form or a
+// confirmation prompt, so global shortcuts should be left alone.
+func (m PortfolioModel) Editing() bool {
+	return m.form != nil || m.confirmDelete
+}
+
+func (m PortfolioModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.confirmDelete {
+			m.confirmDelete = false
+			if msg.String() == "y" {
+				return m.deleteSelected()
+			}
+			return m, nil
+		}
+		if m.ledger == nil {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "r":
+			return m.Reload()
+		case "t":
+			return m.switchMode(transactionsMode), nil
+		case "g":
+			return m.switchMode(gainsMode), nil
+		case "b":
+			return m.cycleMethod()
+		case "left", "h":
+			if m.mode == gainsMode && m.year > 0 {
+				m.year--
+				m.selected = 0
+			}
+			return m, nil
+		case "right", "l":
+			if m.mode == gainsMode && m.year < len(m.reports)-1 {
+				m.year++
+				m.selected = 0
+			}
+			return m, nil
+		case "x":
+			if m.mode == gainsMode && len(m.reports) > 0 {
+				m.notice = m.exportYear()
+			}
+			return m, nil
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case "down", "j":
+			if m.selected < m.rowCount()-1 {
+				m.selected++
+			}
+			return m, nil
+		case "a":
+			var tx portfolio.Transaction
+			if m.mode == holdingsMode && len(m.positions) > 0 {
+				tx.CoinID = m.positions[m.selected].CoinID
+			}
+			form := newTransactionForm(tx)
+			m.form = &form
+			return m, nil
+		case "e":
+			if m.mode == transactionsMode && m.rowCount() > 0 {
+				form := newTransactionForm(m.transactions()[m.selected])
+				m.form = &form
+			}
+			return m, nil
+		case "d", "delete":
+			if m.mode == transactionsMode && m.rowCount() > 0 {
+				m.confirmDelete = true
+			}
+			return m, nil
+		case "enter":
+			if m.mode != holdingsMode || len(m.positions) == 0 {
+				return m, nil
+			}
+			coinID := m.positions[m.selected].CoinID
+			return m, func() tea.Msg { return OpenCoinMsg(coinID) }
+		}
+
+	case rateLimitTickMsg:
+		// Keep the rate limit countdown ticking until the prices arrive
+		if m.loading {
+			return m, rateLimitTick()
+		}
+		return m, nil
+
+	case positionsMsg:
+		if msg.revision != m.revision {
+			// Priced before the ledger last changed
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.positions = msg.positions
+			m.selected = min(m.selected, max(m.rowCount()-1, 0))
+		}
+		if retry, ok := rateLimitRetry(m.err); ok {
+			return m, retry
+		}
+		return m, nil
+
+	case retryMsg:
+		if m.err == nil {
+			return m, nil
+		}
+		return m.Reload()
+	}
+
+	return m, nil
+}
+
+func (m PortfolioModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.form = nil
+		return m, nil
+	}
+
+	form, cmd, submitted := m.form.update(msg)
+	m.form = &form
+	if !submitted {
+		return m, cmd
+	}
+
+	tx, err := form.transaction()
+	if err == nil {
+		if tx.ID == "" {
+			_, err = m.ledger.Add(tx)
+		} else {
+			err = m.ledger.Update(tx)
+		}
+	}
+	if err != nil {
+		form.err = err
+		return m, nil
+	}
+
+	m.form = nil
+	m.ledgerErr = portfolio.SaveLedger(m.ledger)
+	return m.Reload()
+}
+
+func (m PortfolioModel) deleteSelected() (tea.Model, tea.Cmd) {
+	tx := m.transactions()[m.selected]
+	if err := m.ledger.Delete(tx.ID); err != nil {
+		// Deleting would leave a later sale uncovered
+		m.ledgerErr = err
+		return m, nil
+	}
+
+	m.ledgerErr = portfolio.SaveLedger(m.ledger)
+	m.selected = min(m.selected, max(m.rowCount()-1, 0))
+	return m.Reload()
+}
+
+// transactions lists the ledger newest first.
+func (m PortfolioModel) transactions() []portfolio.Transaction {
+	transactions := make([]portfolio.Transaction, 0, len(m.ledger.Transactions))
+	for i := len(m.ledger.Transactions) - 1; i >= 0; i-- {
+		transactions = append(transactions, m.ledger.Transactions[i])
+	}
+	return transactions
+}
+
+func (m PortfolioModel) rowCount() int {
+	switch m.mode {
+	case transactionsMode:
+		return len(m.ledger.Transactions)
+	case gainsMode:
+		if len(m.reports) == 0 {
+			return 0
+		}
+		return len(m.reports[m.year].Disposals)
+	}
+	return len(m.positions)
+}
+
+// switchMode shows the table for mode, or goes back to the holdings if it
+// is already shown.
+func (m PortfolioModel) switchMode(mode portfolioMode) PortfolioModel {
+	if m.mode == mode {
+		mode = holdingsMode
+	}
+	m.mode = mode
+	m.selected = 0
+	m.notice = ""
+	return m
+}
+
+// cycleMethod switches to the next cost-basis method and saves it with the
+// ledger, since holdings and gains all depend on it.
+func (m PortfolioModel) cycleMethod() (PortfolioModel, tea.Cmd) {
+	methods := portfolio.CostBasisMethods
+	for i, method := range methods {
+		if method == m.ledger.Method() {
+			m.ledger.CostBasis = methods[(i+1)%len(methods)]
+			break
+		}
+	}
+
+	m.ledgerErr = portfolio.SaveLedger(m.ledger)
+	return m.Reload()
+}
+
+// refreshReports recomputes realized gains from the ledger, keeping the
+// selected year where it still exists and otherwise showing the latest.
+func (m *PortfolioModel) refreshReports() {
+	year := 0
+	if m.year < len(m.reports) {
+		year = m.reports[m.year].Year
+	}
+
+	disposals, err := m.ledger.Disposals()
+	if err != nil {
+		m.reports = nil
+		return
+	}
+
+	m.reports = portfolio.Report(disposals)
+	m.year = max(len(m.reports)-1, 0)
+	for i, report := range m.reports {
+		if report.Year == year {
+			m.year = i
+		}
+	}
+	if m.mode == gainsMode {
+		m.selected = min(m.selected, max(m.rowCount()-1, 0))
+	}
+}
+
+// exportYear writes the selected year's disposals to a CSV file in the
+// working directory, returning a message describing the outcome.
+func (m PortfolioModel) exportYear() string {
+	report := m.reports[m.year]
+	path, err := filepath.Abs(fmt.Sprintf("neongecko-gains-%d-%s.csv", report.Year, m.ledger.Method()))
+	if err != nil {
+		return "❌ " + err.Error()
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Sprintf("❌ failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+
+	if err := portfolio.WriteCSV(file, report.Disposals); err != nil {
+		return "❌ " + err.Error()
+	}
+	return fmt.Sprintf("✅ Exported %d disposals to %s", len(report.Disposals), path)
+}
+
+func (m PortfolioModel) View() string {
+	if m.ledger == nil {
+		return BaseStyle.Render(renderError(m.ledgerErr))
+	}
+	if m.loading {
+		return BaseStyle.
+			Align(lipgloss.Center).
+			Render(loadingText(m.client, "Pricing portfolio..."))
+	}
+
+	title := TitleStyle.Render("💼 Portfolio")
+	currency := m.ledger.Currency
+
+	var body string
+	switch {
+	case m.form != nil:
+		body = m.form.view(currency)
+	case m.err != nil:
+		body = renderError(m.err)
+	case len(m.ledger.Transactions) == 0:
+		body = BoxStyle.Render(HelpStyle.Render("No transactions yet. Press a to add one."))
+	case m.mode == gainsMode:
+		body = m.renderGains()
+	case m.mode == transactionsMode:
+		body = BoxStyle.Render(strings.Join(m.visibleRows(renderTable(transactionColumns(currency), m.transactions(), m.selected)), "\n"))
+	default:
+		body = lipgloss.JoinVertical(lipgloss.Center,
+			m.renderTotals(),
+			BoxStyle.Render(strings.Join(m.visibleRows(renderTable(positionColumns, m.positions, m.selected)), "\n")),
+		)
+	}
+
+	var status string
+	if m.notice != "" {
+		status = ValueStyle.Render(m.notice)
+	}
+	if m.ledgerErr != nil {
+		status = ErrorStyle.Render("❌ " + m.ledgerErr.Error())
+	}
+	if m.confirmDelete {
+		status = WarningStyle.Render("Delete this transaction? (y/n)")
+	}
+
+	var help string
+	switch {
+	case m.form != nil:
+		help = "Tab/↑/↓: field • ←/→: type • Enter: next/save • Ctrl+S: save • ESC: cancel"
+	case m.mode == transactionsMode:
+		help = "↑/↓: select • a: add • e: edit • d: delete • t: holdings • ESC: home"
+	case m.mode == gainsMode:
+		help = "←/→: year • x: export CSV • b: cost basis • g: holdings • ESC: home"
+	default:
+		help = "↑/↓: select • Enter: open • a: add • t: transactions • g: gains • b: cost basis • r: refresh • ESC: home"
+	}
+	help = HelpStyle.Render(fmt.Sprintf("%s • %s • Prices in %s", help, m.ledger.Method(), strings.ToUpper(currency)))
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		body,
+		status,
+		help,
+	)
+
+	return BaseStyle.
+		Align(lipgloss.Center).
+		Render(content)
+}
+
+func (m PortfolioModel) renderTotals() string {
+	var value, cost, unrealized, realized float64
+	for _, position := range m.positions {
+		realized += position.RealizedPnL
+		if !position.Priced {
+			// Without a price its cost would count as a total loss
+			continue
+		}
+		value += position.Value
+		cost += position.CostBasis
+		unrealized += position.UnrealizedPnL
+	}
+
+	currency := m.ledger.Currency
+	unrealizedText, unrealizedStyle := formatPnL(unrealized, currency)
+	realizedText, realizedStyle := formatPnL(realized, currency)
+
+	return LabelStyle.Render("Value: ") + ValueStyle.Render(FormatCurrency(value, currency)) +
+		LabelStyle.Render("   Cost: ") + ValueStyle.Render(FormatCurrency(cost, currency)) +
+		LabelStyle.Render("   Unrealized: ") + unrealizedStyle.Render(unrealizedText) +
+		LabelStyle.Render("   Realized: ") + realizedStyle.Render(realizedText)
+}
+
+func (m PortfolioModel) renderGains() string {
+	if len(m.reports) == 0 {
+		return BoxStyle.Render(HelpStyle.Render("No sales yet, so no realized gains to report."))
+	}
+
+	report := m.reports[m.year]
+	currency := m.ledger.Currency
+	shortText, shortStyle := formatPnL(report.ShortTermGain, currency)
+	longText, longStyle := formatPnL(report.LongTermGain, currency)
+
+	summary := LabelStyle.Render(fmt.Sprintf("%d (%s)", report.Year, m.ledger.Method())) +
+		LabelStyle.Render("   Proceeds: ") + ValueStyle.Render(FormatCurrency(report.Proceeds, currency)) +
+		LabelStyle.Render("   Basis: ") + ValueStyle.Render(FormatCurrency(report.Basis, currency)) +
+		LabelStyle.Render("   Short term: ") + shortStyle.Render(shortText) +
+		LabelStyle.Render("   Long term: ") + longStyle.Render(longText)
+
+	rows := renderTable(disposalColumns(currency), report.Disposals, m.selected)
+	return lipgloss.JoinVertical(lipgloss.Center,
+		summary,
+		BoxStyle.Render(strings.Join(m.visibleRows(rows), "\n")),
+	)
+}
+
+// visibleRows keeps the header and a window of rows around the selection
+// that fits the terminal height.
+func (m PortfolioModel) visibleRows(rows []string) []string {
+	header, body := rows[0], rows[1:]
+
+	// Title, totals, box chrome and help take about 20 lines
+	capacity := max(m.height-20, 5)
+	start := max(0, m.selected-capacity+1)
+	end := min(len(body), start+capacity)
+
+	return append([]string{header}, body[start:end]...)
+}
+
+// formatPnL writes a signed gain or loss in currency.
+func formatPnL(value float64, currency string) (string, lipgloss.Style) {
+	if value > 0 {
+		return "+" + FormatCurrency(value, currency), PositiveStyle
+	} else if value < 0 {
+		return "-" + FormatCurrency(math.Abs(value), currency), NegativeStyle
+	}
+	return FormatCurrency(0, currency), ValueStyle
+}
+
+// formatQuantity writes a coin amount with up to eight decimals.
+func formatQuantity(quantity float64) string {
+	text := fmt.Sprintf("%.8f", quantity)
+	text = strings.TrimRight(text, "0")
+	return strings.TrimSuffix(text, ".")
+}
+
+// Messages
+type positionsMsg struct {
+	revision  int
+	positions []portfolio.Position
+	err       error
+}
+
+func (m PortfolioModel) valuate() tea.Cmd {
+	revision := m.revision
+	holdings, err := m.ledger.Holdings()
+	currency := m.ledger.Currency
+
+	return func() tea.Msg {
+		if err != nil {
+			return positionsMsg{revision: revision, err: err}
+		}
+		positions, err := portfolio.Valuate(context.Background(), m.client, currency, holdings)
+		return positionsMsg{revision: revision, positions: positions, err: err}
+	}
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/portfolio"
+)
+
+// Fields of the transaction form, in tab order.
+const (
+	fieldCoin = iota
+	fieldType
+	fieldQuantity
+	fieldPrice
+	fieldFee
+	fieldDate
+	fieldNote
+	fieldCount
+)
+
+var fieldLabels = [fieldCount]string{"Coin", "Type", "Quantity", "Price", "Fee", "Date", "Note"}
+
+// dateLayouts are the date formats the form accepts, most specific first.
+var dateLayouts = []string{"2006-01-02 15:04", "2006-01-02"}
+
+// transactionForm adds or edits one ledger transaction. The type field is a
+// selector cycled with ←/→; every other field is free text.
+type transactionForm struct {
+	editing string // ID of the transaction being edited; "" when adding
+	inputs  [fieldCount]textinput.Model
+	txType  int // Index into portfolio.TransactionTypes
+	focus   int
+	err     error
+}
+
+func newTransactionForm(tx portfolio.Transaction) transactionForm {
+	form := transactionForm{editing: tx.ID}
+
+	placeholders := [fieldCount]string{
+		fieldCoin:     "CoinGecko ID, e.g. bitcoin",
+		fieldQuantity: "0.5",
+		fieldPrice:    "Per coin",
+		fieldFee:      "0",
+		fieldDate:     "YYYY-MM-DD",
+		fieldNote:     "Optional",
+	}
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 50
+		input.Width = 30
+		form.inputs[i] = input
+	}
+
+	form.inputs[fieldCoin].SetValue(tx.CoinID)
+	if tx.Quantity > 0 {
+		form.inputs[fieldQuantity].SetValue(formatNumber(tx.Quantity))
+	}
+	if tx.Price > 0 {
+		form.inputs[fieldPrice].SetValue(formatNumber(tx.Price))
+	}
+	if tx.Fee > 0 {
+		form.inputs[fieldFee].SetValue(formatNumber(tx.Fee))
+	}
+	form.inputs[fieldNote].SetValue(tx.Note)
+
+	date := tx.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	form.inputs[fieldDate].SetValue(date.Format(dateLayouts[1]))
+	if date.Hour() != 0 || date.Minute() != 0 {
+		form.inputs[fieldDate].SetValue(date.Format(dateLayouts[0]))
+	}
+
+	for i, t := range portfolio.TransactionTypes {
+		if t == tx.Type {
+			form.txType = i
+		}
+	}
+
+	// Start on the first field that still needs filling in
+	if tx.CoinID != "" {
+		form.focus = fieldType
+	}
+	form.inputs[form.focus].Focus()
+	return form
+}
+
+// update handles a key press, reporting whether the form was submitted.
+func (f transactionForm) update(msg tea.KeyMsg) (transactionForm, tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		if f.focus < fieldCount-1 {
+			return f.focusOn(f.focus + 1), textinput.Blink, false
+		}
+		return f, nil, true
+	case "tab", "down":
+		return f.focusOn((f.focus + 1) % fieldCount), textinput.Blink, false
+	case "shift+tab", "up":
+		return f.focusOn((f.focus + fieldCount - 1) % fieldCount), textinput.Blink, false
+	case "ctrl+s":
+		return f, nil, true
+	}
+
+	if f.focus == fieldType {
+		switch msg.String() {
+		case "left", "h":
+			f.txType = (f.txType + len(portfolio.TransactionTypes) - 1) % len(portfolio.TransactionTypes)
+		case "right", "l", " ":
+			f.txType = (f.txType + 1) % len(portfolio.TransactionTypes)
+		}
+		return f, nil, false
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return f, cmd, false
+}
+
+func (f transactionForm) focusOn(field int) transactionForm {
+	f.inputs[f.focus].Blur()
+	f.focus = field
+	f.inputs[f.focus].Focus()
+	return f
+}
+
+// transaction parses the form into a transaction, keeping the ID of the one
+// being edited.
+func (f transactionForm) transaction() (portfolio.Transaction, error) {
+	tx := portfolio.Transaction{
+		ID:     f.editing,
+		CoinID: strings.ToLower(strings.TrimSpace(f.inputs[fieldCoin].Value())),
+		Type:   portfolio.TransactionTypes[f.txType],
+		Note:   strings.TrimSpace(f.inputs[fieldNote].Value()),
+	}
+
+	var err error
+	if tx.Quantity, err = parseAmount(f.inputs[fieldQuantity].Value(), "quantity", true); err != nil {
+		return tx, err
+	}
+	// Transfers may leave the price blank for a zero cost basis
+	priceRequired := tx.Type == portfolio.Buy || tx.Type == portfolio.Sell
+	if tx.Price, err = parseAmount(f.inputs[fieldPrice].Value(), "price", priceRequired); err != nil {
+		return tx, err
+	}
+	if tx.Fee, err = parseAmount(f.inputs[fieldFee].Value(), "fee", false); err != nil {
+		return tx, err
+	}
+	if tx.Date, err = parseDate(f.inputs[fieldDate].Value()); err != nil {
+		return tx, err
+	}
+
+	return tx, tx.Validate()
+}
+
+func (f transactionForm) view(currency string) string {
+	title := "Add Transaction"
+	if f.editing != "" {
+		title = "Edit Transaction"
+	}
+
+	lines := []string{HeaderStyle.Render(title), ""}
+	for i := range f.inputs {
+		label := fieldLabels[i]
+		if i == fieldPrice || i == fieldFee {
+			label = fmt.Sprintf("%s (%s)", label, strings.ToUpper(currency))
+		}
+
+		value := f.inputs[i].View()
+		if i == fieldType {
+			value = f.typeView()
+		}
+
+		marker := "  "
+		if i == f.focus {
+			marker = "▸ "
+		}
+		lines = append(lines, LabelStyle.Render(fmt.Sprintf("%s%-12s ", marker, label))+value)
+	}
+
+	if f.err != nil {
+		lines = append(lines, "", ErrorStyle.Render("❌ "+f.err.Error()))
+	}
+
+	return BoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (f transactionForm) typeView() string {
+	var options []string
+	for i, t := range portfolio.TransactionTypes {
+		if i == f.txType {
+			options = append(options, WarningStyle.Render(transactionLabel(t)))
+		} else {
+			options = append(options, HelpStyle.Render(transactionLabel(t)))
+		}
+	}
+	return strings.Join(options, ValueStyle.Render(" "))
+}
+
+func transactionLabel(t portfolio.TransactionType) string {
+	switch t {
+	case portfolio.TransferIn:
+		return "Transfer In"
+	case portfolio.TransferOut:
+		return "Transfer Out"
+	}
+	return strings.ToUpper(string(t[:1])) + string(t[1:])
+}
+
+// parseAmount reads a non-negative number, treating blank input as zero
+// unless the field is required.
+func parseAmount(text, field string, required bool) (float64, error) {
+	text = strings.ReplaceAll(strings.TrimSpace(text), ",", "")
+	if text == "" {
+		if required {
+			return 0, fmt.Errorf("%s is required", field)
+		}
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid %s %q", field, text)
+	}
+	return value, nil
+}
+
+func parseDate(text string) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	for _, layout := range dateLayouts {
+		if date, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", text)
+}
+
+// formatNumber writes value without trailing zeros, for editing.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,15 @@
+package ui
+
+import "testing"
+
+func TestParseAmountRejectsNonFinite(t *testing.T) {
+	for _, text := range []string{"nan", "NaN", "inf", "+Inf", "-inf", "infinity"} {
+		if value, err := parseAmount(text, "quantity", true); err == nil {
+			t.Errorf("parseAmount(%q) = %v, want an error", text, value)
+		}
+	}
+
+	if value, err := parseAmount("1,250.5", "price", true); err != nil || value != 1250.5 {
+		t.Errorf("parseAmount(%q) = %v, %v, want 1250.5", "1,250.5", value, err)
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"neongecko/api"
+)
+
+// rateLimitTickMsg re-renders a loading view so the rate limit countdown
+// stays current while a request is queued.
+type rateLimitTickMsg struct{}
+
+func rateLimitTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return rateLimitTickMsg{}
+	})
+}
+
+// loadingText appends the rate limit wait, if any, to a loading message.
+func loadingText(provider api.Provider, text string) string {
+	reporter, ok := provider.(api.RateLimitReporter)
+	if !ok {
+		return text
+	}
+
+	pending := reporter.RateLimitPending()
+	if pending <= 0 {
+		return text
+	}
+
+	seconds := int(math.Ceil(pending.Seconds()))
+	return text + "\n\n" + HelpStyle.Render(fmt.Sprintf("Waiting for rate limit (%ds)...", seconds))
+}
@@ -120,6 +120,17 @@ var (
 		Foreground(red).
 		Background(GetTimeBasedBg()).
 		Bold(true)
+
+	// Crosshair style for chart cursors
+	CrosshairStyle = lipgloss.NewStyle().
+		Foreground(powderBlue).
+		Background(GetTimeBasedBg())
+
+	// Warning style for recoverable problems
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(peach).
+		Background(GetTimeBasedBg()).
+		Bold(true)
 )
 
 func FormatChange(value float64) (string, lipgloss.Style) {
@@ -131,15 +142,22 @@ func FormatChange(value float64) (string, lipgloss.Style) {
 	return "0.00%", ValueStyle
 }
 
-func FormatCurrency(value float64) string {
+// FormatCurrency writes value in currency, abbreviating large amounts and
+// using the currency's own symbol and sub-unit precision.
+func FormatCurrency(value float64, currency string) string {
+	format := formatFor(currency)
+
+	var amount string
 	if value >= 1e12 {
-		return fmt.Sprintf("$%.2fT", value/1e12)
+		amount = fmt.Sprintf("%.2fT", value/1e12)
 	} else if value >= 1e9 {
-		return fmt.Sprintf("$%.2fB", value/1e9)
+		amount = fmt.Sprintf("%.2fB", value/1e9)
 	} else if value >= 1e6 {
-		return fmt.Sprintf("$%.2fM", value/1e6)
+		amount = fmt.Sprintf("%.2fM", value/1e6)
 	} else if value >= 1e3 {
-		return fmt.Sprintf("$%.2fK", value/1e3)
+		amount = fmt.Sprintf("%.2fK", value/1e3)
+	} else {
+		amount = fmt.Sprintf("%.*f", format.precision(value), value)
 	}
-	return fmt.Sprintf("$%.2f", value)
+	return format.apply(amount)
 }
\ No newline at end of file
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/models"
+)
+
+// tableColumn is one column of a table of T rows.
+type tableColumn[T any] struct {
+	title string
+	width int
+	left  bool // Left-align instead of right-align
+	cell  func(row T) (string, lipgloss.Style)
+}
+
+// coinColumn is one column of a coin table.
+type coinColumn = tableColumn[models.Coin]
+
+var (
+	rankColumn = coinColumn{title: "#", width: 4, cell: func(coin models.Coin) (string, lipgloss.Style) {
+		if coin.MarketCapRank == 0 {
+			return "-", ValueStyle
+		}
+		return fmt.Sprint(coin.MarketCapRank), ValueStyle
+	}}
+	nameColumn = coinColumn{title: "Coin", width: 24, left: true, cell: func(coin models.Coin) (string, lipgloss.Style) {
+		return fmt.Sprintf("%s (%s)", coin.Name, strings.ToUpper(coin.Symbol)), ValueStyle
+	}}
+	priceColumn = coinColumn{title: "Price", width: 14, cell: func(coin models.Coin) (string, lipgloss.Style) {
+		return FormatCurrency(coin.CurrentPrice, coin.Currency), ValueStyle
+	}}
+	change24hColumn = changeColumn("24h", func(coin models.Coin) float64 { return coin.PriceChangePercentage24h })
+	change7dColumn  = changeColumn("7d", func(coin models.Coin) float64 { return coin.PriceChangePercentage7d })
+	change30dColumn = changeColumn("30d", func(coin models.Coin) float64 { return coin.PriceChangePercentage30d })
+	marketCapColumn = coinColumn{title: "Market Cap", width: 12, cell: func(coin models.Coin) (string, lipgloss.Style) {
+		return FormatCurrency(coin.MarketCap, coin.Currency), ValueStyle
+	}}
+	volumeColumn = coinColumn{title: "Volume", width: 12, cell: func(coin models.Coin) (string, lipgloss.Style) {
+		return FormatCurrency(coin.TotalVolume, coin.Currency), ValueStyle
+	}}
+)
+
+func changeColumn(title string, value func(coin models.Coin) float64) coinColumn {
+	return coinColumn{title: title, width: 9, cell: func(coin models.Coin) (string, lipgloss.Style) {
+		return FormatChange(value(coin))
+	}}
+}
+
+// renderCoinTable lays coins out under a header row, marking the row at
+// selected. Cells are truncated to their column width.
+func renderCoinTable(columns []coinColumn, coins []models.Coin, selected int) []string {
+	return renderTable(columns, coins, selected)
+}
+
+// renderTable lays rows out under a header row, marking the row at
+// selected. Cells are truncated to their column width.
+func renderTable[T any](columns []tableColumn[T], rows []T, selected int) []string {
+	var header []string
+	for _, column := range columns {
+		header = append(header, LabelStyle.Render(column.pad(column.title)))
+	}
+
+	lines := []string{ValueStyle.Render("  ") + strings.Join(header, ValueStyle.Render("  "))}
+	for i, row := range rows {
+		marker := ValueStyle.Render("  ")
+		if i == selected {
+			marker = LabelStyle.Render("▸ ")
+		}
+
+		var cells []string
+		for _, column := range columns {
+			text, style := column.cell(row)
+			cells = append(cells, style.Render(column.pad(text)))
+		}
+		lines = append(lines, marker+strings.Join(cells, ValueStyle.Render("  ")))
+	}
+	return lines
+}
+
+func (c tableColumn[T]) pad(text string) string {
+	if lipgloss.Width(text) > c.width {
+		runes := []rune(text)
+		text = string(runes[:max(c.width-1, 0)]) + "…"
+	}
+
+	padding := strings.Repeat(" ", max(c.width-lipgloss.Width(text), 0))
+	if c.left {
+		return text + padding
+	}
+	return padding + text
+}
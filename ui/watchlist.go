@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"neongecko/api"
+	"neongecko/config"
+	"neongecko/models"
+)
+
+// OpenCoinMsg asks the program to show a coin in the coin view.
+type OpenCoinMsg string
+
+type WatchlistModel struct {
+	client     api.Provider
+	config     *config.Config
+	currency   string
+	currencies []string // Supported vs_currencies, in cycling order
+	coins      []models.Coin
+	selected   int
+	loading    bool
+	err        error
+	width      int
+	height     int
+}
+
+func NewWatchlistModel(cfg *config.Config, provider api.Provider) WatchlistModel {
+	return WatchlistModel{
+		client:   provider,
+		config:   cfg,
+		currency: cfg.GetCurrency(),
+	}
+}
+
+func (m WatchlistModel) Init() tea.Cmd {
+	return m.refresh()
+}
+
+// Reload fetches the favorites again, picking up any added or removed since
+// the view was last shown.
+func (m WatchlistModel) Reload() (WatchlistModel, tea.Cmd) {
+	m.loading = len(m.config.Display.Favorites) > 0
+	m.err = nil
+	return m, m.refresh()
+}
+
+func (m WatchlistModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "r":
+			return m.Reload()
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case "down", "j":
+			if m.selected < len(m.coins)-1 {
+				m.selected++
+			}
+			return m, nil
+		case "enter":
+			if len(m.coins) > 0 {
+				coinID := m.coins[m.selected].ID
+				return m, func() tea.Msg { return OpenCoinMsg(coinID) }
+			}
+		case "d", "delete":
+			if len(m.coins) > 0 {
+				return m.removeSelected()
+			}
+		case "c":
+			return m, cycleCurrency(m.currencies, m.currency, 1)
+		case "C":
+			return m, cycleCurrency(m.currencies, m.currency, -1)
+		}
+
+	case rateLimitTickMsg:
+		// Keep the rate limit countdown ticking until the data arrives
+		if m.loading {
+			return m, rateLimitTick()
+		}
+		return m, nil
+
+	case watchlistMsg:
+		if msg.currency != m.currency {
+			// Fetched before the currency was switched
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.coins = msg.coins
+			m.selected = min(m.selected, max(len(m.coins)-1, 0))
+		}
+		if retry, ok := rateLimitRetry(m.err); ok {
+			return m, retry
+		}
+		return m, nil
+
+	case retryMsg:
+		if m.err == nil {
+			return m, nil
+		}
+		return m.Reload()
+
+	case CurrenciesMsg:
+		m.currencies = msg
+		return m, nil
+
+	case CurrencyChangedMsg:
+		m.currency = string(msg)
+		if m.coins == nil && !m.loading {
+			// Never opened; the next visit loads in the new currency
+			return m, nil
+		}
+		return m.Reload()
+	}
+
+	return m, nil
+}
+
+// removeSelected drops the highlighted coin from the favorites and saves
+// the config.
+func (m WatchlistModel) removeSelected() (WatchlistModel, tea.Cmd) {
+	m.config.RemoveFavorite(m.coins[m.selected].ID)
+	m.coins = append(m.coins[:m.selected:m.selected], m.coins[m.selected+1:]...)
+	m.selected = min(m.selected, max(len(m.coins)-1, 0))
+
+	if err := config.SaveConfig(m.config); err != nil {
+		m.err = err
+	}
+	return m, nil
+}
+
+func (m WatchlistModel) View() string {
+	if m.loading {
+		return BaseStyle.
+			Align(lipgloss.Center).
+			Render(loadingText(m.client, "Loading watchlist..."))
+	}
+
+	title := TitleStyle.Render("⭐ Watchlist")
+
+	var body string
+	switch {
+	case m.err != nil:
+		body = renderError(m.err)
+	case len(m.config.Display.Favorites) == 0:
+		body = ValueStyle.Render("No favorites yet. Open a coin and press f to add it.")
+	default:
+		body = BoxStyle.Render(strings.Join(renderCoinTable(watchlistColumns, m.coins, m.selected), "\n"))
+	}
+
+	help := HelpStyle.Render(fmt.Sprintf("↑/↓: select • Enter: open • d: remove • r: refresh • c/C: currency (%s) • ESC: home",
+		strings.ToUpper(m.currency)))
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		body,
+		help,
+	)
+
+	return BaseStyle.
+		Align(lipgloss.Center).
+		Render(content)
+}
+
+var watchlistColumns = []coinColumn{
+	nameColumn,
+	priceColumn,
+	change24hColumn,
+	change7dColumn,
+	marketCapColumn,
+}
+
+// Messages
+type watchlistMsg struct {
+	currency string
+	coins    []models.Coin
+	err      error
+}
+
+// refresh fetches every favorite, in as few markets requests as a page
+// allows, and returns them in the order they were added.
+func (m WatchlistModel) refresh() tea.Cmd {
+	favorites := append([]string(nil), m.config.Display.Favorites...)
+	if len(favorites) == 0 {
+		return func() tea.Msg {
+			return watchlistMsg{currency: m.currency}
+		}
+	}
+
+	currency := m.currency
+	fetch := func() tea.Msg {
+		var coins []models.Coin
+		for batch := range slices.Chunk(favorites, api.MaxPerPage) {
+			page, err := m.client.GetMarketsContext(context.Background(), api.MarketsQuery{
+				Currency: currency,
+				IDs:      batch,
+				PerPage:  len(batch),
+			})
+			if err != nil {
+				return watchlistMsg{currency: currency, err: err}
+			}
+			coins = append(coins, page...)
+		}
+
+		byID := make(map[string]models.Coin, len(coins))
+		for _, coin := range coins {
+			byID[coin.ID] = coin
+		}
+
+		ordered := make([]models.Coin, 0, len(coins))
+		for _, id := range favorites {
+			if coin, ok := byID[id]; ok {
+				ordered = append(ordered, coin)
+			}
+		}
+		return watchlistMsg{currency: currency, coins: ordered}
+	}
+
+	return tea.Batch(fetch, rateLimitTick())
+}